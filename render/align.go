@@ -0,0 +1,96 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/kward/golib/math"
+	"github.com/kward/tabulate/table"
+)
+
+// PointAligner is implemented by renderers that can line up the decimal points
+// of float columns.
+type PointAligner interface {
+	// SetAlignPoint sets whether the values of float columns are padded on the
+	// right, so that the decimal points of a right-justified column line up.
+	SetAlignPoint(bool)
+}
+
+// pointAligner implements the PointAligner interface, for embedding in
+// renderers.
+type pointAligner struct {
+	alignPoint bool
+}
+
+// SetAlignPoint implements the PointAligner interface.
+func (a *pointAligner) SetAlignPoint(v bool) { a.alignPoint = v }
+
+// fractions returns the width of the widest fractional part, decimal point
+// included, of the numbers in each float column. It returns nil unless decimal
+// points are aligned.
+func (a *pointAligner) fractions(tbl *table.Table) []int {
+	if !a.alignPoint {
+		return nil
+	}
+	fracs := make([]int, len(tbl.ColSizes()))
+	for j := range fracs {
+		if tbl.ColumnType(j) != table.TypeFloat {
+			continue
+		}
+		for _, row := range tbl.Rows() {
+			if isNumber(row, j) {
+				fracs[j] = math.Max(fracs[j], fraction(cellValue(row, j)))
+			}
+		}
+	}
+	return fracs
+}
+
+// values returns the values of a row, with numbers padded on the right to the
+// widest fractional part of their column.
+func (a *pointAligner) values(row *table.Row, fracs []int) []string {
+	vs := row.Values()
+	for j, v := range vs {
+		if j < len(fracs) && fracs[j] > 0 && isNumber(row, j) {
+			vs[j] = v + strings.Repeat(" ", fracs[j]-fraction(v))
+		}
+	}
+	return vs
+}
+
+// sizes returns a copy of the column sizes, grown to hold the padded values of
+// the rows.
+func (a *pointAligner) sizes(sizes []int, rows []*table.Row, fracs []int) []int {
+	ss := append([]int{}, sizes...)
+	if fracs == nil {
+		return ss
+	}
+	for _, row := range rows {
+		if row.IsComment() {
+			continue
+		}
+		for j, v := range a.values(row, fracs) {
+			if j < len(ss) {
+				ss[j] = math.Max(ss[j], table.Width(v))
+			}
+		}
+	}
+	return ss
+}
+
+// isNumber returns true if column j of the row held a number before any
+// formatting. Comment and header rows hold no numbers.
+func isNumber(row *table.Row, j int) bool {
+	if row.IsComment() || row.IsHeader() || j >= row.NumColumns() {
+		return false
+	}
+	return table.ValueType(row.RawValues()[j]).IsNumeric()
+}
+
+// fraction returns the width of the fractional part of a number, decimal point
+// included, or zero if it has no decimal point.
+func fraction(v string) int {
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		return table.Width(v[i:])
+	}
+	return 0
+}
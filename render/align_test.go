@@ -0,0 +1,40 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestRender_AlignPoint(t *testing.T) {
+	tbl, err := table.Split([]string{"x n", "1.5 a", "100 b", "2.25 c"}, " ", -1,
+		table.Header(true),
+		table.ColumnJustifications([]table.Justification{table.JustifyRight}))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		r    Renderer
+		want string
+	}{
+		{&MySQLRenderer{},
+			"+--------+---+\n" +
+				"|      x | n |\n" +
+				"+--------+---+\n" +
+				"|   1.5  | a |\n" +
+				"| 100    | b |\n" +
+				"|   2.25 | c |\n" +
+				"+--------+---+\n"},
+		{&PSQLRenderer{},
+			"   x    | n\n--------+---\n   1.5  | a\n 100    | b\n   2.25 | c\n(3 rows)\n"},
+	} {
+		t.Run(fmt.Sprintf("%s align point", tc.r.Type()), func(t *testing.T) {
+			tc.r.(PointAligner).SetAlignPoint(true)
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/kward/tabulate/table"
+)
+
+// boxLine describes a horizontal line of a box.
+type boxLine struct {
+	left, fill, inner, right string
+}
+
+// BoxStyle describes the characters used to draw a box around a table.
+type BoxStyle struct {
+	name string
+
+	// Horizontal lines. Lines that are nil are not drawn.
+	top     *boxLine // Above the first row.
+	header  *boxLine // Below the header row, and above the footer row.
+	row     *boxLine // Between rows, when requested.
+	section *boxLine // Between sections.
+	bottom  *boxLine // Below the last row.
+
+	// Vertical lines of a row.
+	left, inner, right string
+	pad                string // Padding either side of a value.
+}
+
+// Name returns the name of the style.
+func (s *BoxStyle) Name() string { return s.name }
+
+// Box styles.
+var (
+	BoxSingle = &BoxStyle{
+		name:    "single",
+		top:     &boxLine{"┌", "─", "┬", "┐"},
+		header:  &boxLine{"╞", "═", "╪", "╡"},
+		row:     &boxLine{"├", "─", "┼", "┤"},
+		section: &boxLine{"┝", "━", "┿", "┥"},
+		bottom:  &boxLine{"└", "─", "┴", "┘"},
+		left:    "│", inner: "│", right: "│", pad: " ",
+	}
+	BoxDouble = &BoxStyle{
+		name:    "double",
+		top:     &boxLine{"╔", "═", "╦", "╗"},
+		header:  &boxLine{"╠", "═", "╬", "╣"},
+		row:     &boxLine{"╟", "─", "╫", "╢"},
+		section: &boxLine{"╠", "═", "╬", "╣"},
+		bottom:  &boxLine{"╚", "═", "╩", "╝"},
+		left:    "║", inner: "║", right: "║", pad: " ",
+	}
+	BoxRounded = &BoxStyle{
+		name:    "rounded",
+		top:     &boxLine{"╭", "─", "┬", "╮"},
+		header:  &boxLine{"╞", "═", "╪", "╡"},
+		row:     &boxLine{"├", "─", "┼", "┤"},
+		section: &boxLine{"┝", "━", "┿", "┥"},
+		bottom:  &boxLine{"╰", "─", "┴", "╯"},
+		left:    "│", inner: "│", right: "│", pad: " ",
+	}
+	BoxHeavy = &BoxStyle{
+		name:    "heavy",
+		top:     &boxLine{"┏", "━", "┳", "┓"},
+		header:  &boxLine{"┣", "━", "╋", "┫"},
+		row:     &boxLine{"┠", "─", "╂", "┨"},
+		section: &boxLine{"┣", "━", "╋", "┫"},
+		bottom:  &boxLine{"┗", "━", "┻", "┛"},
+		left:    "┃", inner: "┃", right: "┃", pad: " ",
+	}
+	BoxASCII = &BoxStyle{
+		name:    "ascii",
+		top:     &boxLine{"+", "-", "+", "+"},
+		header:  &boxLine{"+", "=", "+", "+"},
+		row:     &boxLine{"+", "-", "+", "+"},
+		section: &boxLine{"+", "=", "+", "+"},
+		bottom:  &boxLine{"+", "-", "+", "+"},
+		left:    "|", inner: "|", right: "|", pad: " ",
+	}
+	BoxBorderless = &BoxStyle{
+		name:    "borderless",
+		header:  &boxLine{"", "─", "  ", ""},
+		row:     &boxLine{"", "─", "  ", ""},
+		section: &boxLine{"", " ", "  ", ""},
+		inner:   "  ",
+	}
+)
+
+// BoxStyles lists the supported box styles.
+var BoxStyles = []*BoxStyle{BoxSingle, BoxDouble, BoxRounded, BoxHeavy, BoxASCII, BoxBorderless}
+
+// BoxRenderer implements table rendering with a box drawn around the table,
+// and lines drawn between the header, footer, rows and sections.
+type BoxRenderer struct {
+	fitter
+	pointAligner
+	style    *BoxStyle
+	rowLines bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(BoxRenderer)
+var _ Fitter = new(BoxRenderer)
+var _ PointAligner = new(BoxRenderer)
+
+// NewBoxRenderer returns a BoxRenderer drawing with the given style.
+func NewBoxRenderer(style *BoxStyle) *BoxRenderer {
+	return &BoxRenderer{style: style}
+}
+
+// Render implements the Renderer interface. Multi-line values are laid out
+// over several lines.
+func (r *BoxRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	st := r.style
+	fracs := r.fractions(tbl)
+	sizes := r.sizes(tbl.ColSizes(), tbl.Rows(), fracs)
+	overhead := table.Width(st.left) + table.Width(st.right) + 2*len(sizes)*table.Width(st.pad)
+	if len(sizes) > 1 {
+		overhead += (len(sizes) - 1) * table.Width(st.inner)
+	}
+	sizes = r.fit(sizes, overhead)
+	var buf bytes.Buffer
+	for _, sec := range tbl.Sections() {
+		var prev *table.Row
+		for _, row := range sec.Rows() {
+			if row.IsComment() {
+				continue
+			}
+			switch {
+			case prev == nil && buf.Len() > 0:
+				buf.WriteString(r.line(st.section, sizes))
+			case prev != nil && row.IsFooter():
+				buf.WriteString(r.line(st.header, sizes))
+			case prev != nil && r.rowLines && !prev.IsHeader():
+				buf.WriteString(r.line(st.row, sizes))
+			}
+			for _, line := range r.fitRow(r.values(row, fracs), sizes) {
+				buf.WriteString(r.dataRow(tbl, sizes, line))
+			}
+			if row.IsHeader() {
+				buf.WriteString(r.line(st.header, sizes))
+			}
+			prev = row
+		}
+	}
+	if buf.Len() == 0 {
+		return ""
+	}
+	return r.line(st.top, sizes) + buf.String() + r.line(st.bottom, sizes)
+}
+
+// Type implements the Renderer interface.
+func (r *BoxRenderer) Type() string {
+	if r.style == BoxSingle {
+		return "box"
+	}
+	return "box-" + r.style.name
+}
+
+// SectionsSupported implements the Renderer interface.
+func (r *BoxRenderer) SectionsSupported() bool { return true }
+
+// SetRowLines sets whether a line is drawn between every row.
+func (r *BoxRenderer) SetRowLines(v bool) { r.rowLines = v }
+
+// line returns a horizontal line spanning columns of the given sizes.
+func (r *BoxRenderer) line(l *boxLine, sizes []int) string {
+	if l == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.WriteString(l.left)
+	for j, size := range sizes {
+		if j > 0 {
+			buf.WriteString(l.inner)
+		}
+		buf.WriteString(strings.Repeat(l.fill, size+2*len(r.style.pad)))
+	}
+	buf.WriteString(l.right)
+	return r.trim(buf.String()) + "\n"
+}
+
+// dataRow returns a line of values, each justified within its column.
+func (r *BoxRenderer) dataRow(tbl *table.Table, sizes []int, vs []string) string {
+	st := r.style
+	var buf bytes.Buffer
+	buf.WriteString(st.left)
+	for j, size := range sizes {
+		if j > 0 {
+			buf.WriteString(st.inner)
+		}
+		buf.WriteString(st.pad + tbl.Justification(j).Pad(vs[j], size) + st.pad)
+	}
+	buf.WriteString(st.right)
+	return r.trim(buf.String()) + "\n"
+}
+
+// trim removes trailing whitespace from lines without a right border.
+func (r *BoxRenderer) trim(s string) string {
+	if r.style.right != "" {
+		return s
+	}
+	return strings.TrimRight(s, " ")
+}
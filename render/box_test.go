@@ -0,0 +1,70 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestBoxRenderer(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		style    *BoxStyle
+		lines    []string
+		header   bool
+		reset    bool
+		rowLines bool
+		want     string
+	}{
+		{desc: "single", style: BoxSingle, lines: []string{"a bb", "ccc d"},
+			want: "┌─────┬────┐\n│ a   │ bb │\n│ ccc │ d  │\n└─────┴────┘\n"},
+		{desc: "single header", style: BoxSingle, lines: []string{"name uid", "root 0"}, header: true,
+			want: "┌──────┬─────┐\n│ name │ uid │\n╞══════╪═════╡\n│ root │ 0   │\n└──────┴─────┘\n"},
+		{desc: "double row lines", style: BoxDouble, lines: []string{"h", "a", "b"}, header: true, rowLines: true,
+			want: "╔═══╗\n║ h ║\n╠═══╣\n║ a ║\n╟───╢\n║ b ║\n╚═══╝\n"},
+		{desc: "rounded sections", style: BoxRounded, lines: []string{"a", "", "b"}, reset: true,
+			want: "╭───╮\n│ a │\n┝━━━┥\n│ b │\n╰───╯\n"},
+		{desc: "heavy", style: BoxHeavy, lines: []string{"a"},
+			want: "┏━━━┓\n┃ a ┃\n┗━━━┛\n"},
+		{desc: "ascii header", style: BoxASCII, lines: []string{"h", "a"}, header: true,
+			want: "+---+\n| h |\n+===+\n| a |\n+---+\n"},
+		{desc: "borderless", style: BoxBorderless, lines: []string{"name uid", "root 0"}, header: true,
+			want: "name  uid\n────  ───\nroot  0\n"},
+		{desc: "borderless sections", style: BoxBorderless, lines: []string{"a 1", "", "b 2"}, reset: true,
+			want: "a  1\n\nb  2\n"},
+		{desc: "comments only", style: BoxSingle, lines: []string{"# c"},
+			want: ""},
+	} {
+		t.Run(fmt.Sprintf("BoxRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1,
+				table.EnableComments(true),
+				table.Header(tc.header),
+				table.SectionReset(tc.reset))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := NewBoxRenderer(tc.style)
+			r.SetRowLines(tc.rowLines)
+			if got, want := r.Render(tbl), tc.want; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestBoxRenderer_Type(t *testing.T) {
+	for _, tc := range []struct {
+		style *BoxStyle
+		want  string
+	}{
+		{BoxSingle, "box"},
+		{BoxDouble, "box-double"},
+		{BoxBorderless, "box-borderless"},
+	} {
+		if got, want := NewBoxRenderer(tc.style).Type(), tc.want; got != want {
+			t.Errorf("Type() = %q, want %q", got, want)
+		}
+	}
+}
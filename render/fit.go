@@ -0,0 +1,146 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kward/golib/math"
+	"github.com/kward/tabulate/table"
+)
+
+// Overflow describes how values wider than their column are handled.
+type Overflow int
+
+const (
+	OverflowWrap     Overflow = iota // Values are wrapped over several lines.
+	OverflowTruncate                 // Values are truncated with an ellipsis.
+)
+
+// ParseOverflow converts a string (e.g. "wrap") into an Overflow.
+func ParseOverflow(s string) (Overflow, error) {
+	switch strings.ToLower(s) {
+	case "wrap":
+		return OverflowWrap, nil
+	case "truncate":
+		return OverflowTruncate, nil
+	}
+	return OverflowWrap, fmt.Errorf("invalid overflow %q", s)
+}
+
+// Fitter is implemented by renderers that can fit a table within a maximum
+// width.
+type Fitter interface {
+	// SetMaxWidth sets the maximum width of a rendered line. A width of zero
+	// leaves the width unlimited.
+	SetMaxWidth(int)
+	// SetOverflow sets how values wider than their column are handled.
+	SetOverflow(Overflow)
+	// SetColumnWidths sets the minimum and maximum width of each column. A
+	// width of zero, or a missing width, leaves the column unconstrained.
+	SetColumnWidths(min, max []int)
+}
+
+// fitter implements the Fitter interface, for embedding in renderers.
+type fitter struct {
+	maxWidth             int
+	overflow             Overflow
+	minWidths, maxWidths []int
+}
+
+// SetMaxWidth implements the Fitter interface.
+func (f *fitter) SetMaxWidth(v int) { f.maxWidth = v }
+
+// SetOverflow implements the Fitter interface.
+func (f *fitter) SetOverflow(v Overflow) { f.overflow = v }
+
+// SetColumnWidths implements the Fitter interface.
+func (f *fitter) SetColumnWidths(min, max []int) { f.minWidths, f.maxWidths = min, max }
+
+// fit returns the column sizes adjusted to the column widths, and shrunk to fit
+// within the maximum width. The widest columns are shrunk first, but never
+// below their minimum width. The overhead is the width of a line that is not
+// taken by values, e.g. borders and separators.
+func (f *fitter) fit(sizes []int, overhead int) []int {
+	ss := make([]int, len(sizes))
+	floors := make([]int, len(sizes))
+	for j, size := range sizes {
+		if j < len(f.maxWidths) && f.maxWidths[j] > 0 {
+			size = math.Min(size, f.maxWidths[j])
+		}
+		floors[j] = 1
+		if j < len(f.minWidths) && f.minWidths[j] > 0 {
+			floors[j] = f.minWidths[j]
+			size = math.Max(size, f.minWidths[j])
+		}
+		ss[j] = size
+	}
+	if f.maxWidth <= 0 {
+		return ss
+	}
+
+	for excess := overhead + sum(ss) - f.maxWidth; excess > 0; excess-- {
+		widest := -1
+		for j, size := range ss {
+			if size > floors[j] && (widest < 0 || size > ss[widest]) {
+				widest = j
+			}
+		}
+		if widest < 0 {
+			break // Every column is at its minimum width.
+		}
+		ss[widest]--
+	}
+	return ss
+}
+
+// fitRow returns the lines of the values of a row once they are fitted to the
+// column sizes. Each line holds a value for every column.
+func (f *fitter) fitRow(vs []string, sizes []int) [][]string {
+	cells := make([][]string, len(sizes))
+	n := 1
+	for j, size := range sizes {
+		v := ""
+		if j < len(vs) {
+			v = vs[j]
+		}
+		cells[j] = f.fitCell(v, size)
+		n = math.Max(n, len(cells[j]))
+	}
+
+	lines := make([][]string, n)
+	for i := range lines {
+		lines[i] = make([]string, len(sizes))
+		for j := range sizes {
+			if i < len(cells[j]) {
+				lines[i][j] = cells[j][i]
+			}
+		}
+	}
+	return lines
+}
+
+// fitCell returns the lines of a value fitted to a column of the given size.
+// Multi-line values keep their line breaks.
+func (f *fitter) fitCell(s string, size int) []string {
+	lines := []string{}
+	for _, l := range table.Lines(s) {
+		switch {
+		case table.Width(l) <= size:
+			lines = append(lines, l)
+		case f.overflow == OverflowTruncate:
+			lines = append(lines, table.Truncate(l, size, "…"))
+		default:
+			lines = append(lines, table.Wrap(l, size)...)
+		}
+	}
+	return lines
+}
+
+// sum returns the sum of the sizes.
+func sum(sizes []int) int {
+	n := 0
+	for _, size := range sizes {
+		n += size
+	}
+	return n
+}
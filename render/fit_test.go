@@ -0,0 +1,100 @@
+package render
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestFitter_Fit(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		sizes     []int
+		maxWidth  int
+		minWidths []int
+		maxWidths []int
+		want      []int
+	}{
+		{desc: "unlimited", sizes: []int{5, 10}, want: []int{5, 10}},
+		{desc: "fits", sizes: []int{5, 10}, maxWidth: 20, want: []int{5, 10}},
+		{desc: "widest first", sizes: []int{5, 10}, maxWidth: 15, want: []int{5, 7}},
+		{desc: "shared", sizes: []int{5, 10}, maxWidth: 11, want: []int{4, 4}},
+		{desc: "minimum", sizes: []int{5, 10}, maxWidth: 8, minWidths: []int{0, 6}, want: []int{1, 6}},
+		{desc: "minimum widens", sizes: []int{2, 3}, minWidths: []int{4}, want: []int{4, 3}},
+		{desc: "maximum", sizes: []int{5, 10}, maxWidths: []int{0, 4}, want: []int{5, 4}},
+		{desc: "too narrow", sizes: []int{5, 10}, maxWidth: 2, want: []int{1, 1}},
+	} {
+		t.Run(fmt.Sprintf("fit() %s", tc.desc), func(t *testing.T) {
+			f := &fitter{}
+			f.SetMaxWidth(tc.maxWidth)
+			f.SetColumnWidths(tc.minWidths, tc.maxWidths)
+			if got, want := f.fit(tc.sizes, 3), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("= %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_Fit(t *testing.T) {
+	lines := []string{"id,description", "1,the quick brown fox", "2,jumps"}
+	for _, tc := range []struct {
+		desc     string
+		maxWidth int
+		overflow Overflow
+
+		mysql string
+		plain string
+	}{
+		{desc: "unlimited",
+			mysql: "+----+---------------------+\n" +
+				"| id | description         |\n" +
+				"| 1  | the quick brown fox |\n" +
+				"| 2  | jumps               |\n" +
+				"+----+---------------------+\n",
+			plain: "id description\n1  the quick brown fox\n2  jumps\n"},
+		{desc: "wrap", maxWidth: 17,
+			mysql: "+----+----------+\n" +
+				"| id | descript |\n" +
+				"|    | ion      |\n" +
+				"| 1  | the      |\n" +
+				"|    | quick    |\n" +
+				"|    | brown    |\n" +
+				"|    | fox      |\n" +
+				"| 2  | jumps    |\n" +
+				"+----+----------+\n",
+			plain: "id description\n1  the quick\n   brown fox\n2  jumps\n"},
+		{desc: "truncate", maxWidth: 12, overflow: OverflowTruncate,
+			mysql: "+----+-----+\n" +
+				"| id | de… |\n" +
+				"| 1  | th… |\n" +
+				"| 2  | ju… |\n" +
+				"+----+-----+\n",
+			plain: "id descript…\n1  the quic…\n2  jumps\n"},
+	} {
+		tbl, err := table.Split(lines, ",", -1)
+		if err != nil {
+			t.Fatalf("unexpected error; %s", err)
+		}
+
+		t.Run(fmt.Sprintf("MySQLRenderer %s", tc.desc), func(t *testing.T) {
+			r := &MySQLRenderer{}
+			r.SetMaxWidth(tc.maxWidth)
+			r.SetOverflow(tc.overflow)
+			if got, want := r.Render(tbl), tc.mysql; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+
+		t.Run(fmt.Sprintf("PlainRenderer %s", tc.desc), func(t *testing.T) {
+			r := &PlainRenderer{}
+			r.SetOFS(" ")
+			r.SetMaxWidth(tc.maxWidth)
+			r.SetOverflow(tc.overflow)
+			if got, want := r.Render(tbl), tc.plain; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/kward/tabulate/table"
+)
+
+// HTMLComment describes how comment rows are rendered as HTML.
+type HTMLComment int
+
+const (
+	HTMLCommentOmit    HTMLComment = iota // Comments are not rendered.
+	HTMLCommentCaption                    // Comments form the table caption.
+	HTMLCommentMarkup                     // Comments are rendered as <!-- -->.
+)
+
+// ParseHTMLComment converts a string (e.g. "caption") into an HTMLComment.
+func ParseHTMLComment(s string) (HTMLComment, error) {
+	switch strings.ToLower(s) {
+	case "omit":
+		return HTMLCommentOmit, nil
+	case "caption":
+		return HTMLCommentCaption, nil
+	case "markup":
+		return HTMLCommentMarkup, nil
+	}
+	return HTMLCommentOmit, fmt.Errorf("invalid HTML comment style %q", s)
+}
+
+// htmlStyle is the minimal CSS of a standalone document.
+const htmlStyle = `table { border-collapse: collapse; font-family: sans-serif; }
+caption { padding: 0.25em; font-style: italic; }
+th, td { border: 1px solid #999; padding: 0.25em 0.5em; }
+thead th { background: #eee; }
+tfoot td { border-top: 3px double #999; font-weight: bold; }
+tbody + tbody { border-top: 3px double #999; }`
+
+// HTMLRenderer implements table rendering as an HTML table.
+type HTMLRenderer struct {
+	comment    HTMLComment
+	standalone bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(HTMLRenderer)
+
+// Render implements the Renderer interface. Each section is rendered as a
+// separate <tbody>, and the footer row as a <tfoot>.
+func (r *HTMLRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	numCols := len(tbl.ColSizes())
+	var buf bytes.Buffer
+	buf.WriteString("<table>\n")
+	if r.comment == HTMLCommentCaption {
+		captions := []string{}
+		for _, row := range tbl.Rows() {
+			if row.IsComment() {
+				captions = append(captions, htmlEscape(row.Columns()[0].Value()))
+			}
+		}
+		if len(captions) > 0 {
+			fmt.Fprintf(&buf, "<caption>%s</caption>\n", strings.Join(captions, "<br>"))
+		}
+	}
+	if hdr := tbl.Header(); hdr != nil {
+		buf.WriteString("<thead>\n")
+		buf.WriteString(htmlRow(tbl, hdr, numCols, "th"))
+		buf.WriteString("</thead>\n")
+	}
+	for _, sec := range tbl.Sections() {
+		var body bytes.Buffer
+		for _, row := range sec.Rows() {
+			switch {
+			case row.IsHeader() || row.IsFooter():
+				continue
+			case row.IsComment():
+				if r.comment == HTMLCommentMarkup {
+					body.WriteString(htmlComment(row.Columns()[0].Value()))
+				}
+				continue
+			}
+			body.WriteString(htmlRow(tbl, row, numCols, "td"))
+		}
+		if body.Len() > 0 {
+			buf.WriteString("<tbody>\n")
+			buf.Write(body.Bytes())
+			buf.WriteString("</tbody>\n")
+		}
+	}
+	if ftr := tbl.Footer(); ftr != nil {
+		buf.WriteString("<tfoot>\n")
+		buf.WriteString(htmlRow(tbl, ftr, numCols, "td"))
+		buf.WriteString("</tfoot>\n")
+	}
+	buf.WriteString("</table>\n")
+
+	if !r.standalone {
+		return buf.String()
+	}
+	return "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<style>\n" +
+		htmlStyle + "\n</style>\n</head>\n<body>\n" + buf.String() + "</body>\n</html>\n"
+}
+
+// Type implements the Renderer interface.
+func (r *HTMLRenderer) Type() string { return "html" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *HTMLRenderer) SectionsSupported() bool { return true }
+
+// SetComment sets how comment rows are rendered.
+func (r *HTMLRenderer) SetComment(v HTMLComment) { r.comment = v }
+
+// SetStandalone sets whether a full HTML document is rendered, rather than
+// just the table.
+func (r *HTMLRenderer) SetStandalone(v bool) { r.standalone = v }
+
+// htmlRow returns a table row, with each cell wrapped in the given tag.
+func htmlRow(tbl *table.Table, row *table.Row, numCols int, tag string) string {
+	var buf bytes.Buffer
+	buf.WriteString("<tr>")
+	for j := 0; j < numCols; j++ {
+		switch tbl.Justification(j) {
+		case table.JustifyRight:
+			fmt.Fprintf(&buf, `<%s style="text-align: right">`, tag)
+		case table.JustifyCenter:
+			fmt.Fprintf(&buf, `<%s style="text-align: center">`, tag)
+		default:
+			fmt.Fprintf(&buf, "<%s>", tag)
+		}
+		lines := table.Lines(cellValue(row, j))
+		for i, l := range lines {
+			lines[i] = htmlEscape(l)
+		}
+		fmt.Fprintf(&buf, "%s</%s>", strings.Join(lines, "<br>"), tag)
+	}
+	buf.WriteString("</tr>\n")
+	return buf.String()
+}
+
+// htmlEscape escapes a value for use as HTML text. ANSI escape sequences are
+// meaningless in HTML, and are removed.
+func htmlEscape(s string) string {
+	return html.EscapeString(table.StripANSI(s))
+}
+
+// htmlComment returns s as an HTML comment. Double dashes are not allowed in
+// comments, and are broken up.
+func htmlComment(s string) string {
+	s = table.StripANSI(s)
+	for strings.Contains(s, "--") {
+		s = strings.Replace(s, "--", "- -", -1)
+	}
+	return fmt.Sprintf("<!-- %s -->\n", s)
+}
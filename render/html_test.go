@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		lines   []string
+		header  bool
+		reset   bool
+		comment HTMLComment
+		want    string
+	}{
+		{desc: "simple", lines: []string{"a b", "1"},
+			want: "<table>\n<tbody>\n<tr><td>a</td><td>b</td></tr>\n<tr><td>1</td><td></td></tr>\n</tbody>\n</table>\n"},
+		{desc: "escaped", lines: []string{"<b> a&b"},
+			want: "<table>\n<tbody>\n<tr><td>&lt;b&gt;</td><td>a&amp;b</td></tr>\n</tbody>\n</table>\n"},
+		{desc: "header", lines: []string{"name uid", "root 0"}, header: true,
+			want: "<table>\n<thead>\n<tr><th>name</th><th>uid</th></tr>\n</thead>\n<tbody>\n<tr><td>root</td><td>0</td></tr>\n</tbody>\n</table>\n"},
+		{desc: "sections", lines: []string{"a", "", "b"}, reset: true,
+			want: "<table>\n<tbody>\n<tr><td>a</td></tr>\n</tbody>\n<tbody>\n<tr><td>b</td></tr>\n</tbody>\n</table>\n"},
+		{desc: "comment omitted", lines: []string{"# c", "a"},
+			want: "<table>\n<tbody>\n<tr><td>a</td></tr>\n</tbody>\n</table>\n"},
+		{desc: "comment caption", lines: []string{"# c", "a"}, comment: HTMLCommentCaption,
+			want: "<table>\n<caption># c</caption>\n<tbody>\n<tr><td>a</td></tr>\n</tbody>\n</table>\n"},
+		{desc: "comment markup", lines: []string{"# c--d", "a"}, comment: HTMLCommentMarkup,
+			want: "<table>\n<tbody>\n<!-- # c- -d -->\n<tr><td>a</td></tr>\n</tbody>\n</table>\n"},
+	} {
+		t.Run(fmt.Sprintf("HTMLRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1,
+				table.EnableComments(true),
+				table.Header(tc.header),
+				table.SectionReset(tc.reset))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := &HTMLRenderer{}
+			r.SetComment(tc.comment)
+			if got, want := r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestHTMLRenderer_Standalone(t *testing.T) {
+	tbl, err := table.Split([]string{"a 1"}, " ", -1,
+		table.ColumnJustifications([]table.Justification{table.JustifyCenter, table.JustifyRight}))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	r := &HTMLRenderer{}
+	r.SetStandalone(true)
+	got := r.Render(tbl)
+	for _, want := range []string{
+		"<!DOCTYPE html>\n",
+		"<style>\n",
+		`<tr><td style="text-align: center">a</td><td style="text-align: right">1</td></tr>`,
+		"</html>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("= %q, want it to contain %q", got, want)
+		}
+	}
+}
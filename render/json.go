@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/kward/tabulate/table"
+)
+
+// jsonNumberRE matches the JSON number grammar.
+var jsonNumberRE = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// JSONRenderer implements table rendering as a JSON array. If the table has a
+// header, each row is an object keyed by the header names. Otherwise, each row
+// is an array of values.
+type JSONRenderer struct {
+	pretty  bool
+	numbers bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(JSONRenderer)
+
+// Render implements the Renderer interface.
+func (r *JSONRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	buf.WriteRune('[')
+	for i, v := range jsonValues(tbl, r.numbers) {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		buf.Write(v)
+	}
+	buf.WriteRune(']')
+	return string(jsonFormat(buf.Bytes(), r.pretty)) + "\n"
+}
+
+// Type implements the Renderer interface.
+func (r *JSONRenderer) Type() string { return "json" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *JSONRenderer) SectionsSupported() bool { return false }
+
+// SetPretty sets whether the output is indented.
+func (r *JSONRenderer) SetPretty(v bool) { r.pretty = v }
+
+// SetNumbers sets whether numeric values are rendered as JSON numbers, rather
+// than strings.
+func (r *JSONRenderer) SetNumbers(v bool) { r.numbers = v }
+
+// NDJSONRenderer implements table rendering as newline-delimited JSON, with one
+// row per line. Rows are rendered as for the JSONRenderer.
+type NDJSONRenderer struct {
+	pretty  bool
+	numbers bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(NDJSONRenderer)
+
+// Render implements the Renderer interface.
+func (r *NDJSONRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	for _, v := range jsonValues(tbl, r.numbers) {
+		buf.Write(jsonFormat(v, r.pretty))
+		buf.WriteRune('\n')
+	}
+	return buf.String()
+}
+
+// Type implements the Renderer interface.
+func (r *NDJSONRenderer) Type() string { return "ndjson" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *NDJSONRenderer) SectionsSupported() bool { return false }
+
+// SetPretty sets whether each value is indented. Indented values span several
+// lines.
+func (r *NDJSONRenderer) SetPretty(v bool) { r.pretty = v }
+
+// SetNumbers sets whether numeric values are rendered as JSON numbers, rather
+// than strings.
+func (r *NDJSONRenderer) SetNumbers(v bool) { r.numbers = v }
+
+// jsonValues returns a compact JSON value for each data row of the table.
+// Comment, blank, header and footer rows are skipped.
+func jsonValues(tbl *table.Table, numbers bool) [][]byte {
+	var keys []string
+	if hdr := tbl.Header(); hdr != nil {
+		keys = values(hdr, true)
+		for j := len(keys); j < len(tbl.ColSizes()); j++ {
+			keys = append(keys, strconv.Itoa(j+1))
+		}
+	}
+
+	vs := [][]byte{}
+	for _, row := range tbl.Rows() {
+		if !row.IsData() {
+			continue
+		}
+
+		var buf bytes.Buffer
+		cells := rawValues(row)
+		if keys == nil {
+			buf.WriteRune('[')
+			for j, c := range cells {
+				if j > 0 {
+					buf.WriteRune(',')
+				}
+				buf.Write(jsonCell(c, numbers))
+			}
+			buf.WriteRune(']')
+		} else {
+			buf.WriteRune('{')
+			for j, k := range keys {
+				if j > 0 {
+					buf.WriteRune(',')
+				}
+				buf.Write(jsonString(k))
+				buf.WriteRune(':')
+				if j < len(cells) {
+					buf.Write(jsonCell(cells[j], numbers))
+				} else {
+					buf.WriteString("null")
+				}
+			}
+			buf.WriteRune('}')
+		}
+		vs = append(vs, buf.Bytes())
+	}
+	return vs
+}
+
+// jsonCell returns a cell value as a JSON string, or as a JSON number if
+// requested and the value is numeric.
+func jsonCell(s string, numbers bool) []byte {
+	if numbers && jsonNumberRE.MatchString(s) {
+		return []byte(s)
+	}
+	return jsonString(s)
+}
+
+// jsonString returns s as a JSON string. HTML characters are not escaped.
+func jsonString(s string) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // Encoding a string cannot fail.
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// jsonFormat returns a compact JSON value, indented if pretty is set.
+func jsonFormat(v []byte, pretty bool) []byte {
+	if !pretty {
+		return v
+	}
+	var buf bytes.Buffer
+	json.Indent(&buf, v, "", "  ")
+	return buf.Bytes()
+}
@@ -0,0 +1,63 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestJSONRenderers(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		lines   []string
+		header  bool
+		pretty  bool
+		numbers bool
+
+		json   string
+		ndjson string
+	}{
+		{desc: "arrays", lines: []string{"a 1", "# comment", "b"},
+			json:   `[["a","1"],["b"]]` + "\n",
+			ndjson: "[\"a\",\"1\"]\n[\"b\"]\n"},
+		{desc: "objects", lines: []string{"name uid", "root 0", "nobody"}, header: true,
+			json:   `[{"name":"root","uid":"0"},{"name":"nobody","uid":null}]` + "\n",
+			ndjson: "{\"name\":\"root\",\"uid\":\"0\"}\n{\"name\":\"nobody\",\"uid\":null}\n"},
+		{desc: "unnamed columns", lines: []string{"name", "root 0"}, header: true,
+			json:   `[{"name":"root","2":"0"}]` + "\n",
+			ndjson: "{\"name\":\"root\",\"2\":\"0\"}\n"},
+		{desc: "numbers", lines: []string{"a 1 -2.5e3 007 1,000"}, numbers: true,
+			json:   `[["a",1,-2.5e3,"007","1,000"]]` + "\n",
+			ndjson: "[\"a\",1,-2.5e3,\"007\",\"1,000\"]\n"},
+		{desc: "escaping", lines: []string{`<a> "q"`},
+			json:   `[["<a>","\"q\""]]` + "\n",
+			ndjson: "[\"<a>\",\"\\\"q\\\"\"]\n"},
+		{desc: "pretty", lines: []string{"k", "v"}, header: true, pretty: true,
+			json:   "[\n  {\n    \"k\": \"v\"\n  }\n]\n",
+			ndjson: "{\n  \"k\": \"v\"\n}\n"},
+	} {
+		tbl, err := table.Split(tc.lines, " ", -1, table.EnableComments(true), table.Header(tc.header))
+		if err != nil {
+			t.Fatalf("unexpected error; %s", err)
+		}
+
+		t.Run(fmt.Sprintf("JSONRenderer %s", tc.desc), func(t *testing.T) {
+			r := &JSONRenderer{}
+			r.SetPretty(tc.pretty)
+			r.SetNumbers(tc.numbers)
+			if got, want := r.Render(tbl), tc.json; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+
+		t.Run(fmt.Sprintf("NDJSONRenderer %s", tc.desc), func(t *testing.T) {
+			r := &NDJSONRenderer{}
+			r.SetPretty(tc.pretty)
+			r.SetNumbers(tc.numbers)
+			if got, want := r.Render(tbl), tc.ndjson; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/kward/golib/math"
+	"github.com/kward/tabulate/table"
+)
+
+// PSQLRenderer implements table rendering similar to the PostgreSQL psql
+// client. As in psql, numeric columns are right-justified unless justified
+// per column. In expanded mode (psql's \x), each record is printed vertically.
+type PSQLRenderer struct {
+	pointAligner
+	expanded bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(PSQLRenderer)
+var _ PointAligner = new(PSQLRenderer)
+
+// Render implements the Renderer interface.
+func (r *PSQLRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	rows := []*table.Row{}
+	for _, row := range tbl.Rows() {
+		if !row.IsData() {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if r.expanded {
+		return r.renderExpanded(tbl, rows, tbl.Footer())
+	}
+
+	fracs := r.fractions(tbl)
+	sizes := r.sizes(tbl.ColSizes(), rows, fracs)
+	ftr := tbl.Footer()
+	if ftr != nil {
+		sizes = r.sizes(sizes, []*table.Row{ftr}, fracs)
+	}
+	dashes := make([]string, len(sizes))
+	for j, size := range sizes {
+		dashes[j] = strings.Repeat("-", size+2)
+	}
+	rule := strings.Join(dashes, "+") + "\n"
+
+	var buf bytes.Buffer
+	if hdr := tbl.Header(); hdr != nil {
+		cells := make([]string, len(sizes))
+		for j, size := range sizes {
+			cells[j] = table.JustifyCenter.Pad(cellValue(hdr, j), size)
+		}
+		buf.WriteString(psqlLine(cells))
+		buf.WriteString(rule)
+	}
+	for _, row := range rows {
+		buf.WriteString(r.line(tbl, row, sizes, fracs))
+	}
+	if ftr != nil {
+		buf.WriteString(rule)
+		buf.WriteString(r.line(tbl, ftr, sizes, fracs))
+	}
+
+	noun := "rows"
+	if len(rows) == 1 {
+		noun = "row"
+	}
+	fmt.Fprintf(&buf, "(%d %s)\n", len(rows), noun)
+	return buf.String()
+}
+
+// line returns the values of a row as a psql line, each justified within its
+// column.
+func (r *PSQLRenderer) line(tbl *table.Table, row *table.Row, sizes, fracs []int) string {
+	vs := r.values(row, fracs)
+	cells := make([]string, len(sizes))
+	for j, size := range sizes {
+		v := ""
+		if j < len(vs) {
+			v = vs[j]
+		}
+		cells[j] = tbl.NumberJustification(j).Pad(v, size)
+	}
+	return psqlLine(cells)
+}
+
+// renderExpanded renders each row as a block of "name | value" lines. The
+// footer, if any, is rendered as a last block.
+func (r *PSQLRenderer) renderExpanded(tbl *table.Table, rows []*table.Row, ftr *table.Row) string {
+	blocks := rows
+	if ftr != nil {
+		blocks = append(append([]*table.Row{}, rows...), ftr)
+	}
+	names := columnNames(tbl)
+	nameWidth, valueWidth := 0, 0
+	for j, n := range names {
+		nameWidth = math.Max(nameWidth, table.Width(n))
+		for _, row := range blocks {
+			valueWidth = math.Max(valueWidth, table.Width(cellValue(row, j)))
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, row := range blocks {
+		label := fmt.Sprintf("-[ RECORD %d ]", i+1)
+		if row.IsFooter() {
+			label = "-[ FOOTER ]"
+		}
+		if w := table.Width(label); w <= nameWidth+1 {
+			label += strings.Repeat("-", nameWidth+1-w) + "+" + strings.Repeat("-", valueWidth+1)
+		} else {
+			label += strings.Repeat("-", math.Max(0, nameWidth+valueWidth+3-w))
+		}
+		buf.WriteString(label + "\n")
+		for j, n := range names {
+			line := table.JustifyLeft.Pad(n, nameWidth) + " | " + cellValue(row, j)
+			buf.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	if len(rows) == 0 {
+		buf.WriteString("(0 rows)\n")
+	}
+	return buf.String()
+}
+
+// Type implements the Renderer interface.
+func (r *PSQLRenderer) Type() string { return "psql" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *PSQLRenderer) SectionsSupported() bool { return false }
+
+// SetExpanded sets whether each record is printed vertically.
+func (r *PSQLRenderer) SetExpanded(v bool) { r.expanded = v }
+
+// psqlLine returns justified cells joined as a psql line, without trailing
+// whitespace.
+func psqlLine(cells []string) string {
+	return strings.TrimRight(" "+strings.Join(cells, " | "), " ") + "\n"
+}
@@ -0,0 +1,52 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestPSQLRenderer(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		lines    []string
+		header   bool
+		expanded bool
+		want     string
+	}{
+		{desc: "header", lines: []string{"name uid", "root 0", "nobody -2"}, header: true,
+			want: "  name  | uid\n--------+-----\n root   |   0\n nobody |  -2\n(2 rows)\n"},
+		{desc: "numbers", lines: []string{"a b n", "x 1 10", "y 2 7"}, header: true,
+			want: " a | b | n\n---+---+----\n x | 1 | 10\n y | 2 |  7\n(2 rows)\n"},
+		{desc: "one row", lines: []string{"id", "1"}, header: true,
+			want: " id\n----\n 1\n(1 row)\n"},
+		{desc: "no rows", lines: []string{"id"}, header: true,
+			want: " id\n----\n(0 rows)\n"},
+		{desc: "no header", lines: []string{"a b", "c"},
+			want: " a | b\n c |\n(2 rows)\n"},
+		{desc: "expanded", lines: []string{"name uid", "root 0", "nobody -2"}, header: true, expanded: true,
+			want: "-[ RECORD 1 ]\nname | root\nuid  | 0\n-[ RECORD 2 ]\nname | nobody\nuid  | -2\n"},
+		{desc: "expanded wide values", lines: []string{"user shell", "root /bin/sh"}, header: true, expanded: true,
+			want: "-[ RECORD 1 ]--\nuser  | root\nshell | /bin/sh\n"},
+		{desc: "expanded wide names", lines: []string{"authentication_method id", "password 1"}, header: true, expanded: true,
+			want: "-[ RECORD 1 ]---------+---------\nauthentication_method | password\nid                    | 1\n"},
+		{desc: "expanded no header", lines: []string{"a b"}, expanded: true,
+			want: "-[ RECORD 1 ]\n1 | a\n2 | b\n"},
+	} {
+		t.Run(fmt.Sprintf("PSQLRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1,
+				table.Header(tc.header),
+				table.ColumnJustifications([]table.Justification{table.JustifyLeft, table.JustifyRight}))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := &PSQLRenderer{}
+			r.SetExpanded(tc.expanded)
+			if got, want := r.Render(tbl), tc.want; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
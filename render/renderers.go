@@ -3,19 +3,32 @@ package render
 import (
 	"bytes"
 	"encoding/csv"
+	"strconv"
 	"strings"
 
-	kstrings "github.com/kward/golib/strings"
+	"github.com/kward/golib/math"
 	"github.com/kward/tabulate/table"
 )
 
 // Renderers holds a populated list of renderers.
 var Renderers = []Renderer{
+	NewBoxRenderer(BoxSingle),
+	NewBoxRenderer(BoxASCII),
+	NewBoxRenderer(BoxBorderless),
+	NewBoxRenderer(BoxDouble),
+	NewBoxRenderer(BoxHeavy),
+	NewBoxRenderer(BoxRounded),
 	&CSVRenderer{},
+	&HTMLRenderer{},
+	&JSONRenderer{},
 	&MarkdownRenderer{},
 	&MySQLRenderer{},
+	&NDJSONRenderer{},
 	&PlainRenderer{},
+	&PSQLRenderer{},
+	&SQLRenderer{},
 	&SQLite3Renderer{},
+	&VerticalRenderer{},
 }
 
 // Renderer is an interface that allows the contents of a Table to be rendered.
@@ -29,8 +42,11 @@ type Renderer interface {
 	SectionsSupported() bool
 }
 
-// MySQLRenderer implements table rendering as CSV.
-type CSVRenderer struct{}
+// CSVRenderer implements table rendering as CSV.
+type CSVRenderer struct {
+	stripANSI bool
+	footer    bool
+}
 
 // Ensure the Renderer interface is implemented.
 var _ Renderer = new(CSVRenderer)
@@ -41,24 +57,47 @@ func (r *CSVRenderer) Render(tbl *table.Table) string {
 		return ""
 	}
 
-	buf := new(bytes.Buffer)
-	w := csv.NewWriter(buf)
+	// Sections are separated by an empty record.
+	return strings.Join(r.RenderSections(tbl), "\n")
+}
 
-	for _, row := range tbl.Rows() {
-		if row.IsComment() {
-			continue
+// RenderSections renders each section of the table as a separate CSV document.
+// Sections without any records are skipped.
+func (r *CSVRenderer) RenderSections(tbl *table.Table) []string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return nil
+	}
+
+	docs := []string{}
+	for _, sec := range tbl.Sections() {
+		buf := new(bytes.Buffer)
+		w := csv.NewWriter(buf)
+		for _, row := range sec.Rows() {
+			if row.IsComment() || (row.IsFooter() && !r.footer) {
+				continue
+			}
+			w.Write(values(row, r.stripANSI))
+		}
+		w.Flush()
+		if buf.Len() > 0 {
+			docs = append(docs, buf.String())
 		}
-		w.Write(row.Values())
 	}
-	w.Flush()
-	return buf.String()
+	return docs
 }
 
 // Type implements the Renderer interface.
 func (r *CSVRenderer) Type() string { return "csv" }
 
 // SectionsSupported implements the Renderer interface.
-func (r *CSVRenderer) SectionsSupported() bool { return false }
+func (r *CSVRenderer) SectionsSupported() bool { return true }
+
+// SetStripANSI sets whether ANSI escape sequences are stripped from values.
+func (r *CSVRenderer) SetStripANSI(v bool) { r.stripANSI = v }
+
+// SetFooter sets whether the footer row is rendered. Being computed from the
+// data, it is not rendered by default.
+func (r *CSVRenderer) SetFooter(v bool) { r.footer = v }
 
 // MarkdownRenderer implements table rendering in Markdown format.
 type MarkdownRenderer struct{}
@@ -66,33 +105,84 @@ type MarkdownRenderer struct{}
 // Ensure the Renderer interface is implemented.
 var _ Renderer = new(MarkdownRenderer)
 
-// Render implements the Renderer interface.
+// Render implements the Renderer interface. The lines of multi-line values are
+// separated by <br> tags, and the values of the footer row are bold.
 func (r *MarkdownRenderer) Render(tbl *table.Table) string {
 	if tbl == nil || tbl.NumRows() == 0 {
 		return ""
 	}
 
-	var buf bytes.Buffer
-	for _, row := range tbl.Rows() {
-		if row.IsComment() {
-			continue
+	// Each section is a separate table, separated by an empty line.
+	tables := []string{}
+	for _, sec := range tbl.Sections() {
+		min := 0
+		if hasHeader(sec) {
+			min = 3 // The delimiter row needs three characters.
+		}
+		sizes := minSizes(sec.ColSizes(), min) // A copy, as sizes may grow.
+		rows := [][]string{}
+		for _, row := range sec.Rows() {
+			if row.IsComment() {
+				rows = append(rows, nil)
+				continue
+			}
+			vs := markdownValues(row)
+			for j, v := range vs {
+				sizes[j] = math.Max(sizes[j], table.Width(v))
+			}
+			rows = append(rows, vs)
 		}
 
-		for j, col := range row.Columns() {
-			if j == 0 {
-				buf.WriteRune('|')
+		var buf bytes.Buffer
+		for i, row := range sec.Rows() {
+			if row.IsComment() {
+				continue
 			}
-			if j < row.NumColumns() {
-				s := row.Sizes()[j]
-				if s > 0 {
-					buf.WriteRune(' ')
-					buf.WriteString(kstrings.Stretch(col.Value(), ' ', s))
-				}
-				buf.WriteString(" |")
+			buf.WriteString(boxedRow(tbl, sizes, rows[i]))
+			if row.IsHeader() {
+				buf.WriteString(markdownDelimiter(tbl, sizes))
 			}
 		}
-		buf.WriteRune('\n')
+		if buf.Len() > 0 {
+			tables = append(tables, buf.String())
+		}
 	}
+	return strings.Join(tables, "\n")
+}
+
+// markdownValues returns the values of a row, with pipes escaped and the lines
+// of multi-line values joined by <br> tags. Values of the footer row are bold.
+func markdownValues(row *table.Row) []string {
+	vs := make([]string, row.NumColumns())
+	for j, c := range row.Columns() {
+		vs[j] = strings.Replace(strings.Join(c.Lines(), "<br>"), "|", `\|`, -1)
+		if row.IsFooter() && vs[j] != "" {
+			vs[j] = "**" + vs[j] + "**"
+		}
+	}
+	return vs
+}
+
+// markdownDelimiter returns the delimiter row that follows the header row. The
+// justification of each column is indicated with colons.
+func markdownDelimiter(tbl *table.Table, sizes []int) string {
+	var buf bytes.Buffer
+	buf.WriteRune('|')
+	for j, size := range sizes {
+		d := []byte(strings.Repeat("-", size))
+		switch tbl.Justification(j) {
+		case table.JustifyLeft:
+			d[0] = ':'
+		case table.JustifyRight:
+			d[size-1] = ':'
+		case table.JustifyCenter:
+			d[0], d[size-1] = ':', ':'
+		}
+		buf.WriteRune(' ')
+		buf.Write(d)
+		buf.WriteString(" |")
+	}
+	buf.WriteRune('\n')
 	return buf.String()
 }
 
@@ -100,13 +190,19 @@ func (r *MarkdownRenderer) Render(tbl *table.Table) string {
 func (r *MarkdownRenderer) Type() string { return "markdown" }
 
 // SectionsSupported implements the Renderer interface.
-func (r *MarkdownRenderer) SectionsSupported() bool { return false }
+func (r *MarkdownRenderer) SectionsSupported() bool { return true }
 
-// MySQLRenderer implements table rendering similar to MySQL.
-type MySQLRenderer struct{}
+// MySQLRenderer implements table rendering similar to MySQL. Tables may be
+// fitted within a maximum width.
+type MySQLRenderer struct {
+	fitter
+	pointAligner
+}
 
 // Ensure the Renderer interface is implemented.
 var _ Renderer = new(MySQLRenderer)
+var _ Fitter = new(MySQLRenderer)
+var _ PointAligner = new(MySQLRenderer)
 
 // Render implements the Renderer interface.
 func (r *MySQLRenderer) Render(tbl *table.Table) string {
@@ -114,60 +210,63 @@ func (r *MySQLRenderer) Render(tbl *table.Table) string {
 		return ""
 	}
 
-	sectionBreak := "+"
-	for _, size := range tbl.Rows()[0].Sizes() {
-		if size > 0 {
-			size += 2
-		} else {
-			size += 1
-		}
-		sectionBreak += kstrings.Stretch("", '-', size)
-		sectionBreak += "+"
-	}
-	sectionBreak += "\n"
-
-	var buf bytes.Buffer
-	for _, row := range tbl.Rows() {
-		if row.IsComment() {
-			continue
+	// Each section is drawn as a separate box, separated by an empty line.
+	fracs := r.fractions(tbl)
+	boxes := []string{}
+	for _, sec := range tbl.Sections() {
+		sizes := r.fit(r.sizes(sec.ColSizes(), sec.Rows(), fracs), 1+3*len(sec.ColSizes()))
+		sectionBreak := "+"
+		for _, size := range sizes {
+			if size > 0 {
+				size += 2
+			} else {
+				size += 1
+			}
+			sectionBreak += strings.Repeat("-", size)
+			sectionBreak += "+"
 		}
+		sectionBreak += "\n"
 
-		for j, col := range row.Columns() {
-			if j == 0 {
-				buf.WriteRune('|')
+		var buf bytes.Buffer
+		for _, row := range sec.Rows() {
+			if row.IsComment() {
+				continue
 			}
-			if j < row.NumColumns() {
-				s := row.Sizes()[j]
-				if s > 0 {
-					buf.WriteRune(' ')
-					buf.WriteString(kstrings.Stretch(col.Value(), ' ', s))
-				}
-				buf.WriteString(" |")
+			if row.IsFooter() && buf.Len() > 0 {
+				buf.WriteString(sectionBreak)
+			}
+			for _, line := range r.fitRow(r.values(row, fracs), sizes) {
+				buf.WriteString(boxedRow(tbl, sizes, line))
+			}
+			if row.IsHeader() {
+				buf.WriteString(sectionBreak)
 			}
 		}
-		buf.WriteRune('\n')
-	}
-
-	var s string
-	if buf.Len() > 0 {
-		s = sectionBreak + buf.String() + sectionBreak
+		if buf.Len() > 0 {
+			boxes = append(boxes, sectionBreak+buf.String()+sectionBreak)
+		}
 	}
-	return s
+	return strings.Join(boxes, "\n")
 }
 
 // Type implements the Renderer interface.
 func (r *MySQLRenderer) Type() string { return "mysql" }
 
 // SectionsSupported implements the Renderer interface.
-func (r *MySQLRenderer) SectionsSupported() bool { return false }
+func (r *MySQLRenderer) SectionsSupported() bool { return true }
 
-// PlainRenderer implements table rendering as rows and columns of text.
+// PlainRenderer implements table rendering as rows and columns of text. Tables
+// may be fitted within a maximum width. A rule is drawn above the footer.
 type PlainRenderer struct {
+	fitter
+	pointAligner
 	ofs string
 }
 
 // Ensure the Renderer interface is implemented.
 var _ Renderer = new(PlainRenderer)
+var _ Fitter = new(PlainRenderer)
+var _ PointAligner = new(PlainRenderer)
 
 // Render implements the Renderer interface.
 func (r *PlainRenderer) Render(tbl *table.Table) string {
@@ -175,28 +274,32 @@ func (r *PlainRenderer) Render(tbl *table.Table) string {
 		return ""
 	}
 
+	fracs := r.fractions(tbl)
 	var buf bytes.Buffer
-	for _, row := range tbl.Rows() {
-		if row.IsComment() {
-			buf.WriteString(row.Columns()[0].Value())
-			buf.WriteRune('\n')
-			continue
+	for i, sec := range tbl.Sections() {
+		if i > 0 {
+			buf.WriteRune('\n') // Sections are separated by an empty line.
 		}
-
-		tail := "" // Tail to append on *next* loop.
-		for j, col := range row.Columns() {
-			if col.Length() == 0 { // If this col is empty, remaining cols will be too.
-				break
+		sizes := r.sizes(sec.ColSizes(), sec.Rows(), fracs)
+		sizes = r.fit(sizes, table.Width(r.ofs)*(len(sizes)-1))
+		for _, row := range sec.Rows() {
+			if row.IsComment() {
+				buf.WriteString(row.Columns()[0].Value())
+				buf.WriteRune('\n')
+				continue
 			}
-			if j > 0 {
-				tail += r.ofs
+			if row.IsFooter() {
+				// The footer is set apart from the body rows by a rule.
+				rule := make([]string, len(sizes))
+				for j, size := range sizes {
+					rule[j] = strings.Repeat("-", size)
+				}
+				buf.WriteString(r.line(tbl, sizes, rule))
 			}
-			buf.WriteString(tail + col.Value())
-			if j < row.NumColumns()-1 {
-				tail = strings.Repeat(" ", tbl.ColSizes()[j]-col.Length())
+			for _, line := range r.fitRow(r.values(row, fracs), sizes) {
+				buf.WriteString(r.line(tbl, sizes, line))
 			}
 		}
-		buf.WriteRune('\n')
 	}
 	return buf.String()
 }
@@ -210,8 +313,34 @@ func (r *PlainRenderer) SectionsSupported() bool { return true }
 // SetOFS sets the OFS separator.
 func (r *PlainRenderer) SetOFS(ofs string) { r.ofs = ofs }
 
-// MySQLRenderer implements table rendering similar to SQLite3.
-type SQLite3Renderer struct{}
+// line returns a line of values, each justified within its column. Trailing
+// empty values are not padded.
+func (r *PlainRenderer) line(tbl *table.Table, sizes []int, vs []string) string {
+	last := -1
+	for j, v := range vs {
+		if v != "" {
+			last = j
+		}
+	}
+
+	var buf bytes.Buffer
+	tail := "" // Tail to append on *next* loop.
+	for j := 0; j <= last; j++ {
+		if j > 0 {
+			tail += r.ofs
+		}
+		left, right := tbl.Justification(j).Padding(table.Width(vs[j]), sizes[j])
+		buf.WriteString(tail + strings.Repeat(" ", left) + vs[j])
+		tail = strings.Repeat(" ", right)
+	}
+	buf.WriteRune('\n')
+	return buf.String()
+}
+
+// SQLite3Renderer implements table rendering similar to SQLite3.
+type SQLite3Renderer struct {
+	stripANSI bool
+}
 
 // Ensure the Renderer interface is implemented.
 var _ Renderer = new(SQLite3Renderer)
@@ -224,16 +353,16 @@ func (r *SQLite3Renderer) Render(tbl *table.Table) string {
 
 	var buf bytes.Buffer
 	for _, row := range tbl.Rows() {
-		if row.IsComment() {
+		if row.IsComment() || row.IsFooter() {
 			// Do nothing.
 			continue
 		}
 
-		for j, col := range row.Columns() {
+		for j, v := range values(row, r.stripANSI) {
 			if j > 0 {
 				buf.WriteRune('|')
 			}
-			buf.WriteString(col.Value())
+			buf.WriteString(v)
 		}
 		buf.WriteRune('\n')
 	}
@@ -245,3 +374,94 @@ func (r *SQLite3Renderer) Type() string { return "sqlite3" }
 
 // SectionsSupported implements the Renderer interface.
 func (r *SQLite3Renderer) SectionsSupported() bool { return false }
+
+// SetStripANSI sets whether ANSI escape sequences are stripped from values.
+func (r *SQLite3Renderer) SetStripANSI(v bool) { r.stripANSI = v }
+
+// boxedRow returns a row of values delimited by '|' characters, with each value
+// justified within its column.
+func boxedRow(tbl *table.Table, sizes []int, vs []string) string {
+	var buf bytes.Buffer
+	buf.WriteRune('|')
+	for j, size := range sizes {
+		if size > 0 {
+			v := ""
+			if j < len(vs) {
+				v = vs[j]
+			}
+			buf.WriteRune(' ')
+			buf.WriteString(tbl.Justification(j).Pad(v, size))
+		}
+		buf.WriteString(" |")
+	}
+	buf.WriteRune('\n')
+	return buf.String()
+}
+
+// hasHeader returns true if the section holds the header row.
+func hasHeader(sec *table.Section) bool {
+	for _, row := range sec.Rows() {
+		if row.IsHeader() {
+			return true
+		}
+	}
+	return false
+}
+
+// minSizes returns a copy of sizes with each size at least min.
+func minSizes(sizes []int, min int) []int {
+	ss := make([]int, len(sizes))
+	for i, s := range sizes {
+		if s < min {
+			s = min
+		}
+		ss[i] = s
+	}
+	return ss
+}
+
+// cellValue returns the value of column j of the row, or an empty string if
+// the row is too short.
+func cellValue(row *table.Row, j int) string {
+	if j < row.NumColumns() {
+		return row.Columns()[j].Value()
+	}
+	return ""
+}
+
+// columnNames returns the name of each column, taken from the header row.
+// Columns without a name are numbered from one.
+func columnNames(tbl *table.Table) []string {
+	names := make([]string, len(tbl.ColSizes()))
+	hdr := tbl.Header()
+	for j := range names {
+		if hdr != nil && cellValue(hdr, j) != "" {
+			names[j] = cellValue(hdr, j)
+		} else {
+			names[j] = strconv.Itoa(j + 1)
+		}
+	}
+	return names
+}
+
+// values returns the cell data of a row, optionally stripped of ANSI escape
+// sequences.
+func values(row *table.Row, stripANSI bool) []string {
+	vs := row.Values()
+	if stripANSI {
+		for i, v := range vs {
+			vs[i] = table.StripANSI(v)
+		}
+	}
+	return vs
+}
+
+// rawValues returns the cell data of a row before any number formatting,
+// stripped of ANSI escape sequences.
+func rawValues(row *table.Row) []string {
+	vs := row.RawValues()
+	for i, v := range vs {
+		vs[i] = table.StripANSI(v)
+	}
+	return vs
+}
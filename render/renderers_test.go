@@ -2,6 +2,7 @@ package render
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/kward/tabulate/table"
@@ -177,3 +178,318 @@ func TestPlainRenderer(t *testing.T) {
 		}
 	}
 }
+
+func TestRender_Justify(t *testing.T) {
+	tbl, err := table.Split([]string{"root 0 0", "nobody -2 -2"}, " ", -1,
+		table.ColumnJustifications([]table.Justification{table.JustifyLeft, table.JustifyRight, table.JustifyCenter}))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		r    Renderer
+		want string
+	}{
+		{&MarkdownRenderer{},
+			"| root   |  0 | 0  |\n| nobody | -2 | -2 |\n"},
+		{&MySQLRenderer{},
+			"+--------+----+----+\n| root   |  0 | 0  |\n| nobody | -2 | -2 |\n+--------+----+----+\n"},
+		{&PlainRenderer{ofs: " "},
+			"root    0 0\nnobody -2 -2\n"},
+	} {
+		t.Run(fmt.Sprintf("%s justified", tc.r.Type()), func(t *testing.T) {
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_Sections(t *testing.T) {
+	lines := []string{"1 22", "", "4444 333"}
+	for _, tc := range []struct {
+		desc  string
+		reset bool
+
+		csv      string
+		markdown string
+		mysql    string
+	}{
+		{"no reset", false,
+			"1,22\n\n4444,333\n",
+			"| 1    | 22  |\n|      |     |\n| 4444 | 333 |\n",
+			"+------+-----+\n| 1    | 22  |\n|      |     |\n| 4444 | 333 |\n+------+-----+\n",
+		},
+		{"reset", true,
+			"1,22\n\n4444,333\n",
+			"| 1 | 22 |\n\n| 4444 | 333 |\n",
+			"+---+----+\n| 1 | 22 |\n+---+----+\n\n+------+-----+\n| 4444 | 333 |\n+------+-----+\n",
+		},
+	} {
+		tbl, err := table.Split(lines, " ", -1, table.SectionReset(tc.reset))
+		if err != nil {
+			t.Fatalf("unexpected error; %s", err)
+		}
+
+		t.Run(fmt.Sprintf("CSVRenderer %s", tc.desc), func(t *testing.T) {
+			r := &CSVRenderer{}
+			if got, want := r.Render(tbl), tc.csv; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+
+		t.Run(fmt.Sprintf("MarkdownRenderer %s", tc.desc), func(t *testing.T) {
+			r := &MarkdownRenderer{}
+			if got, want := r.Render(tbl), tc.markdown; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+
+		t.Run(fmt.Sprintf("MySQLRenderer %s", tc.desc), func(t *testing.T) {
+			r := &MySQLRenderer{}
+			if got, want := r.Render(tbl), tc.mysql; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPlainRenderer_Sections(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		lines []string
+		reset bool
+		out   string
+	}{
+		{"no reset", []string{"1 22", "", "4444 333"}, false, "1    22\n\n4444 333\n"},
+		{"reset", []string{"1 22", "", "4444 333"}, true, "1 22\n\n4444 333\n"},
+		{"reset trailing blank", []string{"1 22", "", "4444 333", ""}, true, "1 22\n\n4444 333\n"},
+	} {
+		t.Run(fmt.Sprintf("PlainRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1, table.SectionReset(tc.reset))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := &PlainRenderer{}
+			r.SetOFS(" ")
+			if got, want := r.Render(tbl), tc.out; got != want {
+				t.Errorf("Render() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCSVRenderer_RenderSections(t *testing.T) {
+	tbl, err := table.Split([]string{"a b", "", "", "c d"}, " ", -1, table.SectionReset(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	r := &CSVRenderer{}
+	got := r.RenderSections(tbl)
+	if want := []string{"a,b\n", "c,d\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RenderSections() = %q, want %q", got, want)
+	}
+}
+
+func TestRender_Header(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		r     Renderer
+		lines []string
+		want  string
+	}{
+		{"header", &MarkdownRenderer{}, []string{"name uid", "root 0"},
+			"| name | uid |\n| :--- | --: |\n| root |   0 |\n"},
+		{"header", &MySQLRenderer{}, []string{"name uid", "root 0"},
+			"+------+-----+\n| name | uid |\n+------+-----+\n| root |   0 |\n+------+-----+\n"},
+		{"escaped pipe", &MarkdownRenderer{}, []string{"a|b uid", "x|y 0"},
+			"| a\\|b | uid |\n| :--- | --: |\n| x\\|y |   0 |\n"},
+	} {
+		t.Run(fmt.Sprintf("%s %s", tc.r.Type(), tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1,
+				table.Header(true),
+				table.ColumnJustifications([]table.Justification{table.JustifyLeft, table.JustifyRight}))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_Width(t *testing.T) {
+	tbl, err := table.Split([]string{"日本 x", "ab y"}, " ", -1)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		r    Renderer
+		want string
+	}{
+		{&MySQLRenderer{},
+			"+------+---+\n| 日本 | x |\n| ab   | y |\n+------+---+\n"},
+		{&PlainRenderer{ofs: " "},
+			"日本 x\nab   y\n"},
+	} {
+		t.Run(fmt.Sprintf("%s wide characters", tc.r.Type()), func(t *testing.T) {
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_ANSI(t *testing.T) {
+	red := "\x1b[31mred\x1b[0m"
+	tbl, err := table.Split([]string{red + " 1", "green 2"}, " ", -1)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		r    Renderer
+		want string
+	}{
+		{&CSVRenderer{},
+			red + ",1\ngreen,2\n"},
+		{&CSVRenderer{stripANSI: true},
+			"red,1\ngreen,2\n"},
+		{&MySQLRenderer{},
+			"+-------+---+\n| " + red + "   | 1 |\n| green | 2 |\n+-------+---+\n"},
+		{&PlainRenderer{ofs: " "},
+			red + "   1\ngreen 2\n"},
+		{&SQLite3Renderer{stripANSI: true},
+			"red|1\ngreen|2\n"},
+	} {
+		t.Run(fmt.Sprintf("%s ANSI", tc.r.Type()), func(t *testing.T) {
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_MultiLine(t *testing.T) {
+	tbl, err := table.NewTable(table.Header(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	tbl.Append([]string{"name", "address"}, []string{"bob", "1 Main St\nSpringfield"}, []string{"al", "2 Elm"})
+
+	for _, tc := range []struct {
+		desc string
+		r    Renderer
+		want string
+	}{
+		{"BoxRenderer", NewBoxRenderer(BoxASCII),
+			"+------+-------------+\n" +
+				"| name | address     |\n" +
+				"+======+=============+\n" +
+				"| bob  | 1 Main St   |\n" +
+				"|      | Springfield |\n" +
+				"| al   | 2 Elm       |\n" +
+				"+------+-------------+\n"},
+		{"CSVRenderer", &CSVRenderer{},
+			"name,address\nbob,\"1 Main St\nSpringfield\"\nal,2 Elm\n"},
+		{"HTMLRenderer", &HTMLRenderer{},
+			"<table>\n<thead>\n<tr><th>name</th><th>address</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td>bob</td><td>1 Main St<br>Springfield</td></tr>\n" +
+				"<tr><td>al</td><td>2 Elm</td></tr>\n</tbody>\n</table>\n"},
+		{"MarkdownRenderer", &MarkdownRenderer{},
+			"| name | address                  |\n" +
+				"| :--- | :----------------------- |\n" +
+				"| bob  | 1 Main St<br>Springfield |\n" +
+				"| al   | 2 Elm                    |\n"},
+		{"MySQLRenderer", &MySQLRenderer{},
+			"+------+-------------+\n" +
+				"| name | address     |\n" +
+				"+------+-------------+\n" +
+				"| bob  | 1 Main St   |\n" +
+				"|      | Springfield |\n" +
+				"| al   | 2 Elm       |\n" +
+				"+------+-------------+\n"},
+		{"PlainRenderer", &PlainRenderer{ofs: " "},
+			"name address\nbob  1 Main St\n     Springfield\nal   2 Elm\n"},
+	} {
+		t.Run(fmt.Sprintf("%s multi-line", tc.desc), func(t *testing.T) {
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestRender_Footer(t *testing.T) {
+	tbl, err := table.Split([]string{"name size", "a 10", "b 2.5"}, " ", -1, table.Header(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	if err := tbl.AppendFooter(table.ParseFooter("Total,sum")...); err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	csvFooter := &CSVRenderer{}
+	csvFooter.SetFooter(true)
+	for _, tc := range []struct {
+		desc string
+		r    Renderer
+		want string
+	}{
+		{"BoxRenderer", NewBoxRenderer(BoxASCII),
+			"+-------+------+\n" +
+				"| name  | size |\n" +
+				"+=======+======+\n" +
+				"| a     | 10   |\n" +
+				"| b     | 2.5  |\n" +
+				"+=======+======+\n" +
+				"| Total | 12.5 |\n" +
+				"+-------+------+\n"},
+		{"CSVRenderer", &CSVRenderer{}, "name,size\na,10\nb,2.5\n"},
+		{"CSVRenderer with footer", csvFooter, "name,size\na,10\nb,2.5\nTotal,12.5\n"},
+		{"HTMLRenderer", &HTMLRenderer{},
+			"<table>\n<thead>\n<tr><th>name</th><th>size</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td>a</td><td>10</td></tr>\n<tr><td>b</td><td>2.5</td></tr>\n</tbody>\n" +
+				"<tfoot>\n<tr><td>Total</td><td>12.5</td></tr>\n</tfoot>\n</table>\n"},
+		{"JSONRenderer", &JSONRenderer{}, `[{"name":"a","size":"10"},{"name":"b","size":"2.5"}]` + "\n"},
+		{"MarkdownRenderer", &MarkdownRenderer{},
+			"| name      | size     |\n" +
+				"| :-------- | :------- |\n" +
+				"| a         | 10       |\n" +
+				"| b         | 2.5      |\n" +
+				"| **Total** | **12.5** |\n"},
+		{"MySQLRenderer", &MySQLRenderer{},
+			"+-------+------+\n" +
+				"| name  | size |\n" +
+				"+-------+------+\n" +
+				"| a     | 10   |\n" +
+				"| b     | 2.5  |\n" +
+				"+-------+------+\n" +
+				"| Total | 12.5 |\n" +
+				"+-------+------+\n"},
+		{"PlainRenderer", &PlainRenderer{ofs: " "},
+			"name  size\na     10\nb     2.5\n----- ----\nTotal 12.5\n"},
+		{"PSQLRenderer", &PSQLRenderer{},
+			" name  | size\n" +
+				"-------+------\n" +
+				" a     |   10\n" +
+				" b     |  2.5\n" +
+				"-------+------\n" +
+				" Total | 12.5\n" +
+				"(2 rows)\n"},
+		{"VerticalRenderer", &VerticalRenderer{},
+			"name: a\nsize: 10\n\nname: b\nsize: 2.5\n" +
+				"*************************** footer ***************************\n" +
+				"name: Total\nsize: 12.5\n"},
+	} {
+		t.Run(fmt.Sprintf("%s footer", tc.desc), func(t *testing.T) {
+			if got, want := tc.r.Render(tbl), tc.want; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/kward/tabulate/table"
+)
+
+// SQLDialect describes the flavour of SQL to render.
+type SQLDialect int
+
+const (
+	SQLite SQLDialect = iota
+	MySQL
+	PostgreSQL
+)
+
+// ParseSQLDialect converts a string (e.g. "postgres") into an SQLDialect.
+func ParseSQLDialect(s string) (SQLDialect, error) {
+	switch strings.ToLower(s) {
+	case "sqlite", "sqlite3":
+		return SQLite, nil
+	case "mysql":
+		return MySQL, nil
+	case "postgres", "postgresql":
+		return PostgreSQL, nil
+	}
+	return SQLite, fmt.Errorf("invalid SQL dialect %q", s)
+}
+
+// SQLRenderer implements table rendering as SQL statements that create and
+// populate a table. Column names are taken from the header row, if present.
+type SQLRenderer struct {
+	dialect   SQLDialect
+	tableName string
+	batchSize int
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(SQLRenderer)
+
+// Render implements the Renderer interface. A CREATE TABLE statement, with the
+// column types inferred by the table, is followed by INSERT statements holding
+// at most the batch size of rows each.
+func (r *SQLRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	numCols := len(tbl.ColSizes())
+	if numCols == 0 {
+		return ""
+	}
+	names := make([]string, numCols)
+	for j := range names {
+		names[j] = fmt.Sprintf("col%d", j+1)
+	}
+	if hdr := tbl.Header(); hdr != nil {
+		for j, v := range values(hdr, true) {
+			if v != "" {
+				names[j] = v
+			}
+		}
+	}
+
+	rows := [][]string{}
+	for _, row := range tbl.Rows() {
+		if !row.IsData() {
+			continue
+		}
+		rows = append(rows, rawValues(row))
+	}
+
+	types := tbl.ColumnTypes()
+	var buf bytes.Buffer
+	name := r.quoteIdent(r.tableName)
+	if r.tableName == "" {
+		name = r.quoteIdent("data")
+	}
+	fmt.Fprintf(&buf, "CREATE TABLE %s (\n", name)
+	cols := make([]string, numCols)
+	for j, n := range names {
+		cols[j] = r.quoteIdent(n)
+		sep := ","
+		if j == numCols-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&buf, "  %s %s%s\n", cols[j], r.typeName(types[j]), sep)
+	}
+	buf.WriteString(");\n")
+
+	batch := r.batchSize
+	if batch <= 0 {
+		batch = len(rows)
+	}
+	for i, row := range rows {
+		if i%batch == 0 {
+			fmt.Fprintf(&buf, "INSERT INTO %s (%s) VALUES\n", name, strings.Join(cols, ", "))
+		}
+		vs := make([]string, numCols)
+		for j := range vs {
+			switch {
+			case j >= len(row) || row[j] == "":
+				vs[j] = "NULL"
+			case types[j].IsNumeric():
+				vs[j] = strings.TrimSpace(row[j])
+			case types[j] == table.TypeBoolean:
+				vs[j] = strings.ToUpper(strings.TrimSpace(row[j]))
+			default:
+				vs[j] = r.quoteString(row[j])
+			}
+		}
+		sep := ","
+		if (i+1)%batch == 0 || i == len(rows)-1 {
+			sep = ";"
+		}
+		fmt.Fprintf(&buf, "  (%s)%s\n", strings.Join(vs, ", "), sep)
+	}
+	return buf.String()
+}
+
+// Type implements the Renderer interface.
+func (r *SQLRenderer) Type() string { return "sql" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *SQLRenderer) SectionsSupported() bool { return false }
+
+// SetDialect sets the SQL dialect.
+func (r *SQLRenderer) SetDialect(v SQLDialect) { r.dialect = v }
+
+// SetTableName sets the name of the created table. The default is "data".
+func (r *SQLRenderer) SetTableName(v string) { r.tableName = v }
+
+// SetBatchSize sets the maximum number of rows per INSERT statement. A size of
+// zero places all rows in a single statement.
+func (r *SQLRenderer) SetBatchSize(v int) { r.batchSize = v }
+
+// quoteIdent returns s quoted as an identifier.
+func (r *SQLRenderer) quoteIdent(s string) string {
+	if r.dialect == MySQL {
+		return "`" + strings.Replace(s, "`", "``", -1) + "`"
+	}
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+// quoteString returns s quoted as a string literal.
+func (r *SQLRenderer) quoteString(s string) string {
+	if r.dialect == MySQL {
+		s = strings.Replace(s, `\`, `\\`, -1)
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// typeName returns the SQL name of a column type.
+func (r *SQLRenderer) typeName(t table.Type) string {
+	switch t {
+	case table.TypeInteger:
+		if r.dialect == SQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case table.TypeFloat:
+		switch r.dialect {
+		case MySQL:
+			return "DOUBLE"
+		case PostgreSQL:
+			return "DOUBLE PRECISION"
+		}
+		return "REAL"
+	case table.TypeBoolean:
+		if r.dialect == SQLite {
+			return "INTEGER"
+		}
+		return "BOOLEAN"
+	case table.TypeTime:
+		switch r.dialect {
+		case MySQL:
+			return "DATETIME"
+		case PostgreSQL:
+			return "TIMESTAMP"
+		}
+	}
+	return "TEXT"
+}
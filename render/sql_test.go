@@ -0,0 +1,100 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestSQLRenderer(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		lines   []string
+		header  bool
+		dialect SQLDialect
+		batch   int
+		want    string
+	}{
+		{"sqlite", []string{"name uid ratio", "root 0 1.5", "o'brien -2"}, true, SQLite, 0,
+			`CREATE TABLE "t" (
+  "name" TEXT,
+  "uid" INTEGER,
+  "ratio" REAL
+);
+INSERT INTO "t" ("name", "uid", "ratio") VALUES
+  ('root', 0, 1.5),
+  ('o''brien', -2, NULL);
+`},
+		{"mysql", []string{`a\b 1`}, false, MySQL, 0,
+			"CREATE TABLE `t` (\n  `col1` TEXT,\n  `col2` BIGINT\n);\n" +
+				"INSERT INTO `t` (`col1`, `col2`) VALUES\n  ('a\\\\b', 1);\n"},
+		{"postgres", []string{`"id" x`, "1.0 y"}, true, PostgreSQL, 0,
+			`CREATE TABLE "t" (
+  """id""" DOUBLE PRECISION,
+  "x" TEXT
+);
+INSERT INTO "t" ("""id""", "x") VALUES
+  (1.0, 'y');
+`},
+		{"boolean and time", []string{"ok at", "true 2024-01-02", "False 2024-01-03T04:05:06"}, true, PostgreSQL, 0,
+			`CREATE TABLE "t" (
+  "ok" BOOLEAN,
+  "at" TIMESTAMP
+);
+INSERT INTO "t" ("ok", "at") VALUES
+  (TRUE, '2024-01-02'),
+  (FALSE, '2024-01-03T04:05:06');
+`},
+		{"empty column", []string{"a", "1 "}, false, SQLite, 0,
+			`CREATE TABLE "t" (
+  "col1" TEXT,
+  "col2" TEXT
+);
+INSERT INTO "t" ("col1", "col2") VALUES
+  ('a', NULL),
+  ('1', NULL);
+`},
+		{"batches", []string{"1", "2", "3"}, false, SQLite, 2,
+			`CREATE TABLE "t" (
+  "col1" INTEGER
+);
+INSERT INTO "t" ("col1") VALUES
+  (1),
+  (2);
+INSERT INTO "t" ("col1") VALUES
+  (3);
+`},
+	} {
+		t.Run(fmt.Sprintf("SQLRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1, table.Header(tc.header))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := &SQLRenderer{}
+			r.SetDialect(tc.dialect)
+			r.SetTableName("t")
+			r.SetBatchSize(tc.batch)
+			if got, want := r.Render(tbl), tc.want; got != want {
+				t.Errorf("= %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSQLRenderer_FormattedNumbers(t *testing.T) {
+	tbl, err := table.Split([]string{"id size", "1001 1000.5"}, " ", -1, table.Header(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	tbl.FormatNumbers(table.NumberFormat{Decimals: 2, Thousands: ","})
+
+	r := &SQLRenderer{}
+	r.SetTableName("t")
+	want := "CREATE TABLE \"t\" (\n  \"id\" INTEGER,\n  \"size\" REAL\n);\n" +
+		"INSERT INTO \"t\" (\"id\", \"size\") VALUES\n  (1001, 1000.5);\n"
+	if got := r.Render(tbl); got != want {
+		t.Errorf("= %q, want %q", got, want)
+	}
+}
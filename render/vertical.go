@@ -0,0 +1,67 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/kward/golib/math"
+	"github.com/kward/tabulate/table"
+)
+
+// VerticalRenderer implements table rendering with one block of
+// "column: value" lines per row, similar to the MySQL client's \G. Records are
+// separated by a blank line, or preceded by a rule if requested. The footer,
+// if any, is a last block, always preceded by a rule.
+type VerticalRenderer struct {
+	rule bool
+}
+
+// Ensure the Renderer interface is implemented.
+var _ Renderer = new(VerticalRenderer)
+
+// Render implements the Renderer interface.
+func (r *VerticalRenderer) Render(tbl *table.Table) string {
+	if tbl == nil || tbl.NumRows() == 0 {
+		return ""
+	}
+
+	names := columnNames(tbl)
+	width := 0
+	for _, n := range names {
+		width = math.Max(width, table.Width(n))
+	}
+
+	var buf bytes.Buffer
+	i := 0
+	stars := strings.Repeat("*", 27)
+	for _, row := range tbl.Rows() {
+		if !row.IsData() && !row.IsFooter() {
+			continue
+		}
+		i++
+		switch {
+		case row.IsFooter():
+			fmt.Fprintf(&buf, "%s footer %s\n", stars, stars)
+		case r.rule:
+			fmt.Fprintf(&buf, "%s %d. row %s\n", stars, i, stars)
+		case i > 1:
+			buf.WriteRune('\n')
+		}
+		for j, n := range names {
+			line := table.JustifyLeft.Pad(n+":", width+1) + " " + cellValue(row, j)
+			buf.WriteString(strings.TrimRight(line, " ") + "\n")
+		}
+	}
+	return buf.String()
+}
+
+// Type implements the Renderer interface.
+func (r *VerticalRenderer) Type() string { return "vertical" }
+
+// SectionsSupported implements the Renderer interface.
+func (r *VerticalRenderer) SectionsSupported() bool { return false }
+
+// SetRule sets whether each record is preceded by a numbered rule, rather than
+// separated by a blank line.
+func (r *VerticalRenderer) SetRule(v bool) { r.rule = v }
@@ -0,0 +1,41 @@
+package render
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kward/tabulate/table"
+)
+
+func TestVerticalRenderer(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		lines  []string
+		header bool
+		rule   bool
+		want   string
+	}{
+		{desc: "header", lines: []string{"name uid", "root 0", "nobody -2"}, header: true,
+			want: "name: root\nuid:  0\n\nname: nobody\nuid:  -2\n"},
+		{desc: "no header", lines: []string{"a b", "c"},
+			want: "1: a\n2: b\n\n1: c\n2:\n"},
+		{desc: "comments", lines: []string{"# users", "name", "root"}, header: true,
+			want: "name: root\n"},
+		{desc: "rule", lines: []string{"name uid", "root 0", "nobody -2"}, header: true, rule: true,
+			want: "*************************** 1. row ***************************\nname: root\nuid:  0\n" +
+				"*************************** 2. row ***************************\nname: nobody\nuid:  -2\n"},
+	} {
+		t.Run(fmt.Sprintf("VerticalRenderer %s", tc.desc), func(t *testing.T) {
+			tbl, err := table.Split(tc.lines, " ", -1, table.EnableComments(true), table.Header(tc.header))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			r := &VerticalRenderer{}
+			r.SetRule(tc.rule)
+			if got, want := r.Render(tbl), tc.want; got != want {
+				t.Errorf("=\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ansiRE matches ANSI escape sequences: CSI sequences (e.g. SGR colours), OSC
+// sequences terminated by BEL or ST (e.g. OSC 8 hyperlinks), and the remaining
+// two character escapes.
+var ansiRE = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+
+// StripANSI returns s with all ANSI escape sequences removed.
+func StripANSI(s string) string {
+	if !strings.ContainsRune(s, '\x1b') {
+		return s
+	}
+	return ansiRE.ReplaceAllString(s, "")
+}
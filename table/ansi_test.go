@@ -0,0 +1,29 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		s    string
+		want string
+	}{
+		{"plain", "abc", "abc"},
+		{"sgr", "\x1b[01;34mbin\x1b[0m", "bin"},
+		{"erase line", "a\x1b[Kb", "ab"},
+		{"osc 8 bel", "\x1b]8;;http://example.com\x07link\x1b]8;;\x07", "link"},
+		{"osc 8 st", "\x1b]8;;http://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+	} {
+		t.Run(fmt.Sprintf("StripANSI() %s", tc.desc), func(t *testing.T) {
+			if got, want := StripANSI(tc.s), tc.want; got != want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tc.s, got, want)
+			}
+			if got, want := Width(tc.s), Width(tc.want); got != want {
+				t.Errorf("Width(%q) = %d, want %d", tc.s, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ReadCSV reads RFC 4180 CSV records into a table.
+//
+// Fields are separated by delim, and may be quoted (see the CSVQuote() and
+// CSVLazyQuotes() options). Quoted fields may hold the delimiter, doubled
+// quotes and newlines. Comment lines are kept as comment rows, and empty lines
+// end a section when sections are enabled.
+//
+// The count `n` limits the number of columns as for Split(); the last column
+// holds the remaining fields, joined by the delimiter.
+func ReadCSV(r io.Reader, delim rune, n int, opts ...func(*options) error) (*Table, error) {
+	if n > MAX_COLS {
+		return nil, fmt.Errorf("column count %d exceeds supported maximum number of columns %d", n, MAX_COLS)
+	}
+
+	tbl, err := NewTable(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating a table; %s", err)
+	}
+	if delim == tbl.opts.csvQuote || delim == '\r' || delim == '\n' || delim == utf8.RuneError {
+		return nil, fmt.Errorf("invalid CSV delimiter %q", delim)
+	}
+	if n == 0 {
+		return tbl, nil
+	}
+
+	cr := &csvReader{
+		r:     bufio.NewReader(r),
+		delim: delim,
+		quote: tbl.opts.csvQuote,
+		lazy:  tbl.opts.csvLazyQuotes,
+	}
+	for {
+		line, err := cr.readLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tbl.opts.enableComments && strings.HasPrefix(line, tbl.opts.commentPrefix):
+			tbl.appendRow(newRow([]string{line}, true))
+		case isBlank(line):
+			tbl.appendBlank()
+		default:
+			rec, err := cr.parse(line)
+			if err != nil {
+				return nil, err
+			}
+			if n > 0 && len(rec) > n {
+				rec = append(rec[:n-1], strings.Join(rec[n-1:], string(delim)))
+			}
+			tbl.appendRow(newRow(rec, false))
+		}
+	}
+	tbl.trimSections()
+	tbl.markHeader()
+	return tbl, nil
+}
+
+// csvReader parses CSV records, one line at a time.
+type csvReader struct {
+	r     *bufio.Reader
+	delim rune
+	quote rune
+	lazy  bool
+	line  int // Number of the line last read.
+}
+
+// readLine returns the next line, without the line ending.
+func (cr *csvReader) readLine() (string, error) {
+	line, err := cr.r.ReadString('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		if err != io.EOF {
+			err = fmt.Errorf("error reading line %d; %s", cr.line+1, err)
+		}
+		return "", err
+	}
+	cr.line++
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// parse the fields of a record beginning with line. Further lines are read
+// while a quoted field spans the end of a line.
+func (cr *csvReader) parse(line string) ([]string, error) {
+	start := cr.line
+	rec := []string{}
+	var field strings.Builder
+	quoted := false // Within a quoted field.
+	begin := true   // At the beginning of a field.
+	for {
+		for i := 0; i < len(line); {
+			c, size := utf8.DecodeRuneInString(line[i:])
+			i += size
+
+			if quoted {
+				if c != cr.quote {
+					field.WriteRune(c)
+					continue
+				}
+				next, nsize := utf8.DecodeRuneInString(line[i:])
+				switch {
+				case i < len(line) && next == cr.quote: // Escaped quote.
+					field.WriteRune(cr.quote)
+					i += nsize
+				case i == len(line) || next == cr.delim: // Closing quote.
+					quoted = false
+				case cr.lazy:
+					field.WriteRune(c)
+				default:
+					return nil, fmt.Errorf("line %d: extraneous %q in quoted field", cr.line, cr.quote)
+				}
+				continue
+			}
+
+			switch {
+			case c == cr.delim:
+				rec = append(rec, field.String())
+				field.Reset()
+				begin = true
+				continue
+			case c == cr.quote && begin:
+				quoted = true
+			case c == cr.quote && !cr.lazy:
+				return nil, fmt.Errorf("line %d: bare %q in non-quoted field", cr.line, cr.quote)
+			default:
+				field.WriteRune(c)
+			}
+			begin = false
+		}
+		if !quoted {
+			break
+		}
+
+		// The quoted field holds a newline.
+		var err error
+		line, err = cr.readLine()
+		if err == io.EOF && cr.lazy {
+			break
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("line %d: quoted field starting on line %d is not terminated", cr.line, start)
+		}
+		if err != nil {
+			return nil, err
+		}
+		field.WriteRune('\n')
+	}
+	return append(rec, field.String()), nil
+}
@@ -0,0 +1,78 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		data  string
+		delim rune
+		n     int
+		opts  []func(*options) error
+
+		values [][]string
+		ok     bool
+	}{
+		// Normal cases.
+		{"simple", "a,b,c\n1,2,3\n", ',', -1, nil,
+			[][]string{{"a", "b", "c"}, {"1", "2", "3"}}, true},
+		{"no trailing newline", "a,b", ',', -1, nil,
+			[][]string{{"a", "b"}}, true},
+		{"crlf", "a,b\r\n1,2\r\n", ',', -1, nil,
+			[][]string{{"a", "b"}, {"1", "2"}}, true},
+		{"quoted delimiter", `a,"b,c",d`, ',', -1, nil,
+			[][]string{{"a", "b,c", "d"}}, true},
+		{"escaped quote", `"say ""hi""",x`, ',', -1, nil,
+			[][]string{{`say "hi"`, "x"}}, true},
+		{"embedded newline", "\"a\nb\",c\nd,e\n", ',', -1, nil,
+			[][]string{{"a\nb", "c"}, {"d", "e"}}, true},
+		{"empty fields", ",,", ',', -1, nil,
+			[][]string{{"", "", ""}}, true},
+		{"semicolon", "a;b", ';', -1, nil,
+			[][]string{{"a", "b"}}, true},
+		{"column limit", "a,b,c,d", ',', 2, nil,
+			[][]string{{"a", "b,c,d"}}, true},
+
+		// Options.
+		{"comment", "# a,b\n1,2", ',', -1, []func(*options) error{EnableComments(true)},
+			[][]string{{"# a,b"}, {"1", "2"}}, true},
+		{"single quote", `'a,b',c`, ',', -1, []func(*options) error{CSVQuote('\'')},
+			[][]string{{"a,b", "c"}}, true},
+		{"lazy bare quote", `a"b,c`, ',', -1, []func(*options) error{CSVLazyQuotes(true)},
+			[][]string{{`a"b`, "c"}}, true},
+		{"lazy extraneous quote", `"a"b",c`, ',', -1, []func(*options) error{CSVLazyQuotes(true)},
+			[][]string{{`a"b`, "c"}}, true},
+
+		// Error cases.
+		{desc: "bare quote", data: `a"b,c`, delim: ',', n: -1},
+		{desc: "extraneous quote", data: `"a"b",c`, delim: ',', n: -1},
+		{desc: "unterminated quote", data: "\"a,b\nc", delim: ',', n: -1},
+		{desc: "quote delimiter", data: "a", delim: '"', n: -1},
+	} {
+		t.Run(fmt.Sprintf("ReadCSV() %s", tc.desc), func(t *testing.T) {
+			tbl, err := ReadCSV(strings.NewReader(tc.data), tc.delim, tc.n, tc.opts...)
+			if err == nil && !tc.ok {
+				t.Fatal("expected an error")
+			}
+			if err != nil {
+				if tc.ok {
+					t.Fatalf("unexpected error; %s", err)
+				}
+				return
+			}
+
+			values := [][]string{}
+			for _, row := range tbl.Rows() {
+				values = append(values, row.Values())
+			}
+			if got, want := values, tc.values; !reflect.DeepEqual(got, want) {
+				t.Errorf("values = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a filter expression, which is true or false for each row of a table.
+//
+// An expression compares operands with the operators ==, !=, <, <=, >, >=, =~
+// (matches a regular expression) and !~ (does not match), and combines the
+// comparisons with &&, || and !, grouped with parentheses. An operand is a
+// column, referenced by one-based index (e.g. $3) or by header name (e.g.
+// shell or $shell), a number, or a quoted string. An operand on its own is true
+// if the value is not empty.
+//
+// Operands are compared as numbers if both are numeric, as dates if both are
+// dates, and as strings otherwise. The type of a column is inferred from its
+// values.
+type Expr struct {
+	root     exprNode
+	operands []*exprOperand
+}
+
+// exprNode is a node of a parsed expression.
+type exprNode interface {
+	eval(row *Row) bool
+}
+
+type (
+	exprOr    struct{ l, r exprNode }
+	exprAnd   struct{ l, r exprNode }
+	exprNot   struct{ n exprNode }
+	exprTruth struct{ o *exprOperand }
+	exprCmp   struct {
+		op   string
+		l, r *exprOperand
+		re   *regexp.Regexp
+	}
+)
+
+func (n *exprOr) eval(row *Row) bool    { return n.l.eval(row) || n.r.eval(row) }
+func (n *exprAnd) eval(row *Row) bool   { return n.l.eval(row) && n.r.eval(row) }
+func (n *exprNot) eval(row *Row) bool   { return !n.n.eval(row) }
+func (n *exprTruth) eval(row *Row) bool { return n.o.value(row) != "" }
+
+func (n *exprCmp) eval(row *Row) bool {
+	a, b := n.l.value(row), n.r.value(row)
+	switch n.op {
+	case "=~":
+		return n.re.MatchString(a)
+	case "!~":
+		return !n.re.MatchString(a)
+	}
+
+	c, ok := 0, true
+	switch {
+	case n.l.typ().IsNumeric() && n.r.typ().IsNumeric():
+		x, errA := strconv.ParseFloat(a, 64)
+		y, errB := strconv.ParseFloat(b, 64)
+		c, ok = compareFloats(x, y), errA == nil && errB == nil
+	case n.l.typ() == TypeTime && n.r.typ() == TypeTime:
+		x, okA := parseTime(a)
+		y, okB := parseTime(b)
+		ok = okA && okB
+		switch {
+		case x.Before(y):
+			c = -1
+		case x.After(y):
+			c = 1
+		}
+	default:
+		c = strings.Compare(a, b)
+	}
+	if !ok {
+		return n.op == "!=" // Values that cannot be compared are unequal.
+	}
+
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0 // ">="
+}
+
+// exprOperand is a column reference or a literal value.
+type exprOperand struct {
+	isColumn bool
+	name     string // Header name of a column referenced by name.
+	col      int    // Zero-based index of a column, once bound.
+	colType  Type   // Type of a column, once bound.
+	lit      string // Literal value.
+}
+
+// value returns the value of the operand for the row.
+func (o *exprOperand) value(row *Row) string {
+	if !o.isColumn {
+		return o.lit
+	}
+	return sortValue(row, o.col)
+}
+
+// typ returns the type of the operand's values.
+func (o *exprOperand) typ() Type {
+	if o.isColumn {
+		return o.colType
+	}
+	return ValueType(o.lit)
+}
+
+// ParseExpr parses a filter expression (e.g. `$3 >= 100 && shell =~ "bash$"`).
+// Column names are resolved when the expression is used to filter a table.
+func ParseExpr(s string) (*Expr, error) {
+	toks, err := lexExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks, expr: &Expr{}}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("unexpected %q in expression", tok)
+	}
+	p.expr.root = root
+	return p.expr, nil
+}
+
+// bind resolves the column operands of the expression against a table.
+func (e *Expr) bind(t *Table) error {
+	types := t.ColumnTypes()
+	hdr := t.Header()
+	for _, o := range e.operands {
+		if !o.isColumn {
+			continue
+		}
+		if o.name != "" {
+			if hdr == nil {
+				return fmt.Errorf("column %q referenced by name requires a header", o.name)
+			}
+			o.col = -1
+			for j, c := range hdr.Columns() {
+				if strings.TrimSpace(StripANSI(c.Value())) == o.name {
+					o.col = j
+					break
+				}
+			}
+			if o.col < 0 {
+				return fmt.Errorf("unknown column %q", o.name)
+			}
+		}
+		o.colType = TypeString
+		if o.col < len(types) {
+			o.colType = types[o.col]
+		}
+	}
+	return nil
+}
+
+// Filter removes the data rows of the table for which the expression is false.
+// Comment, header, footer and blank rows are kept.
+func (t *Table) Filter(e *Expr) error {
+	if err := e.bind(t); err != nil {
+		return err
+	}
+	rows := []*Row{}
+	for _, row := range t.rows {
+		if !row.IsData() || e.root.eval(row) {
+			rows = append(rows, row)
+		}
+	}
+	t.reset(rows)
+	return nil
+}
+
+// exprParser is a recursive descent parser of expression tokens.
+type exprParser struct {
+	toks []exprToken
+	pos  int
+	expr *Expr
+}
+
+// exprToken is a lexical token. Literal tokens hold their unquoted value.
+type exprToken struct {
+	text  string // Token as written.
+	value string // Value of a literal.
+	kind  exprTokenKind
+}
+
+type exprTokenKind int
+
+const (
+	tokOperator exprTokenKind = iota
+	tokColumn                 // $3 or $name
+	tokName                   // A bare header name.
+	tokNumber
+	tokString
+)
+
+// peek returns the text of the next token, or an empty string at the end.
+func (p *exprParser) peek() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	return p.toks[p.pos].text
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *exprParser) accept(op string) bool {
+	if p.pos < len(p.toks) && p.toks[p.pos].kind == tokOperator && p.toks[p.pos].text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	l, err := p.parseAnd()
+	for err == nil && p.accept("||") {
+		var r exprNode
+		if r, err = p.parseAnd(); err == nil {
+			l = &exprOr{l, r}
+		}
+	}
+	return l, err
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	l, err := p.parseUnary()
+	for err == nil && p.accept("&&") {
+		var r exprNode
+		if r, err = p.parseUnary(); err == nil {
+			l = &exprAnd{l, r}
+		}
+	}
+	return l, err
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	switch {
+	case p.accept("!"):
+		n, err := p.parseUnary()
+		return &exprNot{n}, err
+	case p.accept("("):
+		n, err := p.parseOr()
+		if err == nil && !p.accept(")") {
+			err = fmt.Errorf("missing ')' in expression")
+		}
+		return n, err
+	}
+
+	l, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op := p.peek()
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=", "=~", "!~":
+		p.pos++
+	default:
+		return &exprTruth{l}, nil
+	}
+	r, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	n := &exprCmp{op: op, l: l, r: r}
+	if op == "=~" || op == "!~" {
+		if r.isColumn {
+			return nil, fmt.Errorf("the right operand of %s must be a regular expression", op)
+		}
+		if n.re, err = regexp.Compile(r.lit); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q; %s", r.lit, err)
+		}
+	}
+	return n, nil
+}
+
+func (p *exprParser) parseOperand() (*exprOperand, error) {
+	if p.pos >= len(p.toks) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.toks[p.pos]
+	o := &exprOperand{}
+	switch tok.kind {
+	case tokColumn:
+		o.isColumn = true
+		if n, err := strconv.Atoi(tok.value); err == nil {
+			if n < 1 {
+				return nil, fmt.Errorf("invalid column %q; columns start at $1", tok.text)
+			}
+			o.col = n - 1
+		} else {
+			o.name = tok.value
+		}
+	case tokName:
+		o.isColumn = true
+		o.name = tok.value
+	case tokNumber, tokString:
+		o.lit = tok.value
+	default:
+		return nil, fmt.Errorf("unexpected %q in expression", tok.text)
+	}
+	p.pos++
+	p.expr.operands = append(p.expr.operands, o)
+	return o, nil
+}
+
+// exprOperators lists the operators, longest first.
+var exprOperators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "<", ">", "!", "(", ")"}
+
+// lexExpr splits an expression into tokens.
+func lexExpr(s string) ([]exprToken, error) {
+	isNameRune := func(r rune) bool {
+		return r == '_' || r == '.' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	scan := func(s string, f func(rune) bool) int {
+		i := strings.IndexFunc(s, func(r rune) bool { return !f(r) })
+		if i < 0 {
+			return len(s)
+		}
+		return i
+	}
+
+	toks := []exprToken{}
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		tok, n := exprToken{}, 0
+		switch c := s[0]; {
+		case c == '"' || c == '\'':
+			end := 1
+			for end < len(s) && s[end] != c {
+				if s[end] == '\\' && c == '"' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("unterminated string %s", s)
+			}
+			n = end + 1
+			tok = exprToken{kind: tokString, value: s[1:end]}
+			if c == '"' {
+				v, err := strconv.Unquote(s[:n])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string %s; %s", s[:n], err)
+				}
+				tok.value = v
+			}
+		case c == '$':
+			n = 1 + scan(s[1:], isNameRune)
+			if n == 1 {
+				return nil, fmt.Errorf("missing column after '$' in expression")
+			}
+			tok = exprToken{kind: tokColumn, value: s[1:n]}
+		case isDigit(c) || (c == '-' || c == '.') && len(s) > 1 && (isDigit(s[1]) || s[1] == '.'):
+			n = 1
+			for n < len(s) && (s[n] == '.' || s[n] == 'e' || s[n] == 'E' || isDigit(s[n])) {
+				if (s[n] == 'e' || s[n] == 'E') && n+1 < len(s) && (s[n+1] == '+' || s[n+1] == '-') {
+					n++ // The sign of the exponent.
+				}
+				n++
+			}
+			tok = exprToken{kind: tokNumber, value: s[:n]}
+		case isNameRune(rune(c)) || c >= 0x80:
+			n = scan(s, isNameRune)
+			if n == 0 {
+				return nil, fmt.Errorf("unexpected %q in expression", s)
+			}
+			tok = exprToken{kind: tokName, value: s[:n]}
+		default:
+			for _, op := range exprOperators {
+				if strings.HasPrefix(s, op) {
+					n = len(op)
+					break
+				}
+			}
+			if n == 0 {
+				return nil, fmt.Errorf("unexpected %q in expression", s)
+			}
+			tok = exprToken{kind: tokOperator}
+		}
+		tok.text = s[:n]
+		toks = append(toks, tok)
+		s = s[n:]
+	}
+	return toks, nil
+}
@@ -0,0 +1,94 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseExpr(t *testing.T) {
+	for _, tc := range []struct {
+		s  string
+		ok bool
+	}{
+		{`$3 >= 100 && $7 != "/usr/bin/false"`, true},
+		{`shell =~ "bash$"`, true},
+		{`!($1 == 'a' || $2) && $3 < -1.5`, true},
+		{`$0 == 1`, false},
+		{`$1 ==`, false},
+		{`($1 == 1`, false},
+		{`$1 == "a`, false},
+		{`$1 =~ "("`, false},
+		{`$1 =~ $2`, false},
+		{`$1 == 1 2`, false},
+		{`$1 @ 2`, false},
+		{`$3 > 1e-3 && $3 < 2.5E+2`, true},
+	} {
+		t.Run(fmt.Sprintf("ParseExpr(%q)", tc.s), func(t *testing.T) {
+			_, err := ParseExpr(tc.s)
+			if ok := err == nil; ok != tc.ok {
+				t.Errorf("err = %v, want ok %v", err, tc.ok)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	lines := []string{
+		"# passwd",
+		"name uid shell date",
+		"root 0 /bin/bash 2024-01-02",
+		"daemon 1 /usr/bin/false 2023-05-06",
+		"kward 1000 /bin/zsh 2024-11-12",
+		"",
+		"nobody 65534 /usr/bin/false",
+		"bob 999 /bin/bash 2022-12-31",
+	}
+	for _, tc := range []struct {
+		desc string
+		expr string
+		want []string // First column of each row.
+		ok   bool
+	}{
+		{"numeric", `$2 >= 100`, []string{"# passwd", "name", "kward", "", "nobody", "bob"}, true},
+		{"numeric not lexical", `uid < 999`, []string{"# passwd", "name", "root", "daemon", ""}, true},
+		{"string", `$3 != "/usr/bin/false"`, []string{"# passwd", "name", "root", "kward", "", "bob"}, true},
+		{"and", `$2 >= 100 && $3 != "/usr/bin/false"`, []string{"# passwd", "name", "kward", "", "bob"}, true},
+		{"regexp", `shell =~ "bash$"`, []string{"# passwd", "name", "root", "", "bob"}, true},
+		{"not regexp", `$shell !~ 'bash'`, []string{"# passwd", "name", "daemon", "kward", "", "nobody"}, true},
+		{"or not", `!(name == "root" || name == "bob")`, []string{"# passwd", "name", "daemon", "kward", "", "nobody"}, true},
+		{"date", `date >= "2024-01-01"`, []string{"# passwd", "name", "root", "kward", ""}, true},
+		{"exponent", `uid > 1e-3 && uid < 1.5e+3`, []string{"# passwd", "name", "daemon", "kward", "", "bob"}, true},
+		{"truth", `$4`, []string{"# passwd", "name", "root", "daemon", "kward", "", "bob"}, true},
+		{"unknown name", `home == "/"`, nil, false},
+	} {
+		t.Run(fmt.Sprintf("Filter() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, EnableComments(true), Header(true), SectionReset(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			e, err := ParseExpr(tc.expr)
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			err = tbl.Filter(e)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if !tc.ok {
+				return
+			}
+
+			got := []string{}
+			for _, row := range tbl.Rows() {
+				got = append(got, row.Values()[0])
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if got, want := len(tbl.Sections()), 2; got != want {
+				t.Errorf("sections = %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// FixedSplitter splits lines into fixed-width columns. Column positions are
+// measured in terminal display cells.
+type FixedSplitter struct {
+	positions []int // Start position of each column.
+}
+
+// Ensure the Splitter interface is implemented.
+var _ Splitter = new(FixedSplitter)
+
+// NewFixedSplitter returns a Splitter for columns starting at the given
+// positions. Any text before the second position belongs to the first column.
+func NewFixedSplitter(positions []int) (*FixedSplitter, error) {
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("no column positions given")
+	}
+	for i, p := range positions {
+		if p < 0 || (i > 0 && p <= positions[i-1]) {
+			return nil, fmt.Errorf("column positions %v must be increasing and non-negative", positions)
+		}
+	}
+	return &FixedSplitter{positions: positions}, nil
+}
+
+// Positions returns the start position of each column.
+func (s *FixedSplitter) Positions() []int { return s.positions }
+
+// Split implements the Splitter interface. Values are trimmed of surrounding
+// whitespace.
+func (s *FixedSplitter) Split(line string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	positions := s.positions
+	if n > 0 && len(positions) > n {
+		positions = positions[:n]
+	}
+
+	cols := []string{}
+	var buf strings.Builder
+	col, pos := 0, 0
+	for _, r := range line {
+		for col+1 < len(positions) && pos >= positions[col+1] {
+			cols = append(cols, strings.TrimSpace(buf.String()))
+			buf.Reset()
+			col++
+		}
+		buf.WriteRune(r)
+		pos += runewidth.RuneWidth(r)
+	}
+	return append(cols, strings.TrimSpace(buf.String()))
+}
+
+// DetectColumns returns the start positions of columns in already aligned
+// text. A column starts wherever text follows a position that is whitespace on
+// every line, as long as the first line, or more than one line, holds text in
+// the column. Text on a single line past the end of the others, e.g. the
+// arguments of a command, does not start a column. The first column always
+// starts at position zero.
+func DetectColumns(lines []string) []int {
+	texts := [][]bool{} // Positions holding text, for each line with text.
+	used := []bool{}    // Positions holding text on any line.
+	for _, line := range lines {
+		text := []bool{}
+		pos := 0
+		for _, r := range line {
+			w := runewidth.RuneWidth(r)
+			if w == 0 {
+				continue
+			}
+			for len(text) < pos+w {
+				text = append(text, false)
+			}
+			for len(used) < pos+w {
+				used = append(used, false)
+			}
+			if !unicode.IsSpace(r) {
+				for i := pos; i < pos+w; i++ {
+					text[i], used[i] = true, true
+				}
+			}
+			pos += w
+		}
+		if strings.TrimSpace(line) != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	positions := []int{0}
+	seen := false // Text has been seen, so the first column has started.
+	for i := range used {
+		if used[i] && i > 0 && !used[i-1] && seen && startsColumn(texts, used, i) {
+			positions = append(positions, i)
+		}
+		seen = seen || used[i]
+	}
+	return positions
+}
+
+// startsColumn returns true if the run of text starting at position start
+// holds text on the first line, or on more than one line.
+func startsColumn(texts [][]bool, used []bool, start int) bool {
+	end := start
+	for end < len(used) && used[end] {
+		end++
+	}
+	n := 0
+	for i, text := range texts {
+		for j := start; j < end && j < len(text); j++ {
+			if text[j] {
+				if i == 0 {
+					return true
+				}
+				n++
+				break
+			}
+		}
+	}
+	return n > 1
+}
@@ -0,0 +1,73 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFixedSplitter(t *testing.T) {
+	s, err := NewFixedSplitter([]int{0, 6, 12})
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		desc string
+		line string
+		n    int
+		want []string
+	}{
+		{"full", "root  0     /bin/sh", -1, []string{"root", "0", "/bin/sh"}},
+		{"spaces in values", "a b   c d   e f", -1, []string{"a b", "c d", "e f"}},
+		{"short line", "root", -1, []string{"root"}},
+		{"remainder", "root  0     /bin/sh", 2, []string{"root", "0     /bin/sh"}},
+		{"wide characters", "日本  x     y", -1, []string{"日本", "x", "y"}},
+		{"empty", "", -1, []string{""}},
+	} {
+		t.Run(fmt.Sprintf("Split() %s", tc.desc), func(t *testing.T) {
+			if got, want := s.Split(tc.line, tc.n), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("Split(%q, %d) = %q, want %q", tc.line, tc.n, got, want)
+			}
+		})
+	}
+}
+
+func TestNewFixedSplitter_Invalid(t *testing.T) {
+	for _, positions := range [][]int{nil, {0, 5, 5}, {-1, 2}} {
+		if _, err := NewFixedSplitter(positions); err == nil {
+			t.Errorf("NewFixedSplitter(%v) expected an error", positions)
+		}
+	}
+}
+
+func TestDetectColumns(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		lines []string
+		want  []int
+	}{
+		{"simple", []string{
+			"USER   PID COMMAND",
+			"root     1 /sbin/init splash",
+			"kate  1234 vim notes.txt",
+		}, []int{0, 6, 11}},
+		{"arguments past other lines", []string{
+			"USER    PID COMMAND",
+			"root      1 /sbin/init splash",
+			"alice    42 vim",
+		}, []int{0, 8, 12}},
+		{"leading spaces", []string{
+			"  PID TTY",
+			"    1 ?",
+		}, []int{0, 6}},
+		{"single column", []string{"abc", "de"}, []int{0}},
+		{"empty", nil, []int{0}},
+	} {
+		t.Run(fmt.Sprintf("DetectColumns() %s", tc.desc), func(t *testing.T) {
+			if got, want := DetectColumns(tc.lines), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("DetectColumns() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FooterCell describes a cell of a footer row. A cell either aggregates the
+// values of its column, or holds a fixed label.
+type FooterCell struct {
+	Aggregate bool          // Whether the cell is an aggregate.
+	Func      AggregateFunc // Aggregate function of the column's values.
+	Label     string        // Value of a cell that is not an aggregate.
+}
+
+// ParseFooter parses a comma-separated list of footer cells, one per column
+// (e.g. "Total,,sum,avg"). The aggregate functions count, sum, avg, min and max
+// are computed from the values of the column, while any other value is a
+// label. Empty cells are left blank.
+func ParseFooter(s string) []FooterCell {
+	cells := []FooterCell{}
+	for _, f := range strings.Split(s, ",") {
+		c := FooterCell{}
+		switch strings.ToLower(strings.TrimSpace(f)) {
+		case "count":
+			c = FooterCell{Aggregate: true, Func: AggCount}
+		case "sum":
+			c = FooterCell{Aggregate: true, Func: AggSum}
+		case "avg":
+			c = FooterCell{Aggregate: true, Func: AggAvg}
+		case "min":
+			c = FooterCell{Aggregate: true, Func: AggMin}
+		case "max":
+			c = FooterCell{Aggregate: true, Func: AggMax}
+		default:
+			c.Label = f
+		}
+		cells = append(cells, c)
+	}
+	return cells
+}
+
+// AppendFooter appends a footer row to the last section of the table, with the
+// cells computed from the data rows, one per column. Any existing footer is
+// replaced. The footer is the last row of the table, and is not treated as
+// data when the table is sorted, grouped, or has its types inferred. There may
+// be no more cells than columns.
+func (t *Table) AppendFooter(cells ...FooterCell) error {
+	if numCols := len(t.colSizes); len(cells) > numCols {
+		return fmt.Errorf("footer has %d cells, more than the %d columns of the table", len(cells), numCols)
+	}
+
+	rows := []*Row{}
+	for _, row := range t.rows {
+		if !row.IsFooter() {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) != len(t.rows) {
+		t.reset(rows)
+	}
+
+	vs := make([]string, len(cells))
+	for j, c := range cells {
+		if !c.Aggregate {
+			vs[j] = c.Label
+			continue
+		}
+		data := []*Row{}
+		for _, row := range rows {
+			if row.IsData() {
+				data = append(data, row)
+			}
+		}
+		vs[j] = aggregate(data, Aggregate{Func: c.Func, Column: j}, t.ColumnType(j))
+	}
+	row := newRow(vs, false)
+	row.isFooter = true
+	t.appendRow(row)
+	return nil
+}
+
+// Footer returns the footer row, or nil if the table has no footer.
+func (t *Table) Footer() *Row {
+	if n := len(t.rows); n > 0 && t.rows[n-1].IsFooter() {
+		return t.rows[n-1]
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseFooter(t *testing.T) {
+	got := ParseFooter("Total,,SUM,avg")
+	want := []FooterCell{
+		{Label: "Total"},
+		{},
+		{Aggregate: true, Func: AggSum},
+		{Aggregate: true, Func: AggAvg},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFooter() = %v, want %v", got, want)
+	}
+}
+
+func TestAppendFooter(t *testing.T) {
+	lines := []string{"name size", "b 10", "a 2.5", "c x"}
+	for _, tc := range []struct {
+		desc   string
+		footer string
+		want   []string
+	}{
+		{"sum", "Total,sum", []string{"Total", "12.5"}},
+		{"count", ",count", []string{"", "3"}},
+		{"max", "max,max", []string{"c", "x"}},
+	} {
+		t.Run(fmt.Sprintf("AppendFooter() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, Header(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			tbl.AppendFooter(ParseFooter("ignored,count")...) // Replaced below.
+			if err := tbl.AppendFooter(ParseFooter(tc.footer)...); err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			ftr := tbl.Footer()
+			if ftr == nil {
+				t.Fatalf("Footer() = nil")
+			}
+			if got, want := ftr.Values(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("Footer() = %q, want %q", got, want)
+			}
+			if got, want := tbl.NumRows(), len(lines)+1; got != want {
+				t.Errorf("NumRows() = %d, want %d", got, want)
+			}
+
+			// The footer stays in place, and is not data.
+			if err := tbl.Sort(SortKey{Column: 0, Descending: true}); err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			if tbl.Footer() != ftr {
+				t.Errorf("Footer() moved by Sort()")
+			}
+			if got, want := tbl.ColumnType(0), TypeString; got != want {
+				t.Errorf("ColumnType(0) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAppendFooter_FormatNumbers(t *testing.T) {
+	tbl, err := Split([]string{"id size", "1 4000", "2 734.5"}, " ", -1, Header(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	if err := tbl.AppendFooter(ParseFooter("Total,sum")...); err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	tbl.FormatNumbers(NumberFormat{Decimals: -1, Thousands: ","})
+
+	if got, want := tbl.Footer().Values(), []string{"Total", "4,734.5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Footer() = %q, want %q", got, want)
+	}
+	if got, want := tbl.Rows()[1].Values(), []string{"1", "4,000"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Rows()[1] = %q, want %q", got, want)
+	}
+}
+
+func TestAppendFooter_TooManyCells(t *testing.T) {
+	tbl, err := Split([]string{"a b", "1 2"}, " ", -1, Header(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	if err := tbl.AppendFooter(ParseFooter("Total,sum,oops")...); err == nil {
+		t.Errorf("AppendFooter() err = nil, want error")
+	}
+	if tbl.Footer() != nil || len(tbl.ColSizes()) != 2 {
+		t.Errorf("AppendFooter() changed the table on error")
+	}
+}
@@ -0,0 +1,96 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NumberFormat describes how the values of numeric columns are formatted.
+type NumberFormat struct {
+	// Decimals is the number of decimal places of float values. A negative
+	// number leaves the values unchanged.
+	Decimals int
+	// Thousands separates groups of thousands in the integer part of values.
+	// An empty string leaves the values unchanged.
+	Thousands string
+}
+
+// FormatNumbers formats the numbers in the numeric columns of the table. Other
+// values, such as the labels of a footer row, are left unchanged. The values
+// before formatting are kept as the raw values of each row, from which
+// the column types are still inferred.
+func (t *Table) FormatNumbers(f NumberFormat) {
+	types := t.ColumnTypes()
+	rows := make([]*Row, len(t.rows))
+	for i, row := range t.rows {
+		if row.IsComment() || row.IsHeader() {
+			rows[i] = row
+			continue
+		}
+		raws := row.RawValues()
+		vs := append([]string{}, raws...)
+		for j, v := range vs {
+			if j < len(types) && types[j].IsNumeric() && ValueType(v).IsNumeric() {
+				vs[j] = f.format(v, types[j])
+			}
+		}
+		rows[i] = newRow(vs, false)
+		rows[i].raws = raws
+	}
+	t.replaceRows(rows)
+}
+
+// replaceRows replaces each row of the table with its counterpart, keeping the
+// header and sections.
+func (t *Table) replaceRows(rows []*Row) {
+	for i, row := range t.rows {
+		rows[i].isHeader, rows[i].isFooter = row.isHeader, row.isFooter
+	}
+	sectionRows := map[*Row]*Row{}
+	for i, row := range t.rows {
+		sectionRows[row] = rows[i]
+	}
+	for _, sec := range t.sections {
+		for i, row := range sec.rows {
+			sec.rows[i] = sectionRows[row]
+		}
+	}
+	t.reset(rows)
+}
+
+// format returns the value v of a column of type typ, formatted.
+func (f NumberFormat) format(v string, typ Type) string {
+	s := strings.TrimSpace(v)
+	if typ == TypeFloat && f.Decimals >= 0 {
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			s = strconv.FormatFloat(n, 'f', f.Decimals, 64)
+		}
+	}
+	if f.Thousands != "" {
+		s = groupThousands(s, f.Thousands)
+	}
+	return s
+}
+
+// groupThousands returns the number s with the digits of its integer part
+// grouped in thousands.
+func groupThousands(s, sep string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	end := strings.IndexAny(s, ".eE")
+	if end < 0 {
+		end = len(s)
+	}
+	intPart, rest := s[:end], s[end:]
+
+	var buf strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteRune(r)
+	}
+	return sign + buf.String() + rest
+}
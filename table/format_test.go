@@ -0,0 +1,54 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFormatNumbers(t *testing.T) {
+	lines := []string{"name n x", "a 1234567 1.5", "b -42 1234.25", "", "c 7 3"}
+	for _, tc := range []struct {
+		desc   string
+		format NumberFormat
+		want   [][]string
+	}{
+		{"unchanged", NumberFormat{Decimals: -1},
+			[][]string{{"name", "n", "x"}, {"a", "1234567", "1.5"}, {"b", "-42", "1234.25"}, {""}, {"c", "7", "3"}}},
+		{"decimals", NumberFormat{Decimals: 1},
+			[][]string{{"name", "n", "x"}, {"a", "1234567", "1.5"}, {"b", "-42", "1234.2"}, {""}, {"c", "7", "3.0"}}},
+		{"thousands", NumberFormat{Decimals: 2, Thousands: ","},
+			[][]string{{"name", "n", "x"}, {"a", "1,234,567", "1.50"}, {"b", "-42", "1,234.25"}, {""}, {"c", "7", "3.00"}}},
+	} {
+		t.Run(fmt.Sprintf("FormatNumbers() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, Header(true), SectionReset(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			tbl.FormatNumbers(tc.format)
+
+			got := [][]string{}
+			for _, row := range tbl.Rows() {
+				got = append(got, row.Values())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if got, want := len(tbl.Sections()), 2; got != want {
+				t.Errorf("sections = %d, want %d", got, want)
+			}
+			if got, want := tbl.Header().Values()[0], "name"; got != want {
+				t.Errorf("header = %q, want %q", got, want)
+			}
+			if got, want := tbl.Rows()[2].RawValues(), []string{"b", "-42", "1234.25"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("RawValues() = %q, want %q", got, want)
+			}
+			if got, want := tbl.ColumnType(1), TypeInteger; got != want {
+				t.Errorf("ColumnType(1) = %v, want %v", got, want)
+			}
+			if got, want := tbl.ColumnType(2), TypeFloat; got != want {
+				t.Errorf("ColumnType(2) = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kward/golib/math"
+)
+
+// AggregateFunc describes how the values of a group are aggregated.
+type AggregateFunc int
+
+const (
+	AggCount AggregateFunc = iota // Number of rows.
+	AggSum                        // Sum of the numeric values.
+	AggAvg                        // Mean of the numeric values.
+	AggMin                        // Smallest value.
+	AggMax                        // Largest value.
+)
+
+// String implements fmt.Stringer.
+func (f AggregateFunc) String() string {
+	switch f {
+	case AggSum:
+		return "sum"
+	case AggAvg:
+		return "avg"
+	case AggMin:
+		return "min"
+	case AggMax:
+		return "max"
+	}
+	return "count"
+}
+
+// Aggregate describes an aggregate of a column.
+type Aggregate struct {
+	Func   AggregateFunc
+	Column int // Zero-based column index. Unused by AggCount.
+}
+
+// ParseAggregates parses a comma-separated list of aggregates (e.g.
+// "count,sum:3,max:4"). Each aggregate is a function, followed by a colon and a
+// one-based column number for all functions but count.
+func ParseAggregates(s string) ([]Aggregate, error) {
+	aggs := []Aggregate{}
+	for _, f := range strings.Split(s, ",") {
+		name, col := strings.TrimSpace(f), ""
+		if i := strings.Index(name, ":"); i >= 0 {
+			name, col = name[:i], name[i+1:]
+		}
+
+		a := Aggregate{}
+		switch strings.ToLower(name) {
+		case "count":
+			a.Func = AggCount
+		case "sum":
+			a.Func = AggSum
+		case "avg":
+			a.Func = AggAvg
+		case "min":
+			a.Func = AggMin
+		case "max":
+			a.Func = AggMax
+		default:
+			return nil, fmt.Errorf("invalid aggregate %q", f)
+		}
+		switch {
+		case a.Func == AggCount && col == "":
+		case a.Func == AggCount:
+			return nil, fmt.Errorf("invalid aggregate %q; count does not take a column", f)
+		default:
+			n, err := strconv.Atoi(col)
+			if err != nil || n < 1 || n > MAX_COLS {
+				return nil, fmt.Errorf("invalid aggregate %q; a column number is required", f)
+			}
+			a.Column = n - 1
+		}
+		aggs = append(aggs, a)
+	}
+	return aggs, nil
+}
+
+// GroupBy returns a new table with one row per distinct combination of values
+// of the zero-based columns cols, in the order the groups are first seen. Each
+// row holds the values of the group columns, followed by the aggregates. The
+// new table has a header, named after the columns of the header of t if it has
+// one, or the column numbers if not. Comment rows are dropped.
+func (t *Table) GroupBy(cols []int, aggs ...Aggregate) (*Table, error) {
+	numCols := len(t.colSizes)
+	for _, j := range cols {
+		if j < 0 || j >= numCols {
+			return nil, fmt.Errorf("group column %d is outside the %d columns of the table", j+1, numCols)
+		}
+	}
+	for _, a := range aggs {
+		if a.Func != AggCount && (a.Column < 0 || a.Column >= numCols) {
+			return nil, fmt.Errorf("%s column %d is outside the %d columns of the table", a.Func, a.Column+1, numCols)
+		}
+	}
+
+	header := []string{}
+	for _, j := range cols {
+		header = append(header, t.columnName(j))
+	}
+	for _, a := range aggs {
+		if a.Func == AggCount {
+			header = append(header, "count")
+		} else {
+			header = append(header, fmt.Sprintf("%s(%s)", a.Func, t.columnName(a.Column)))
+		}
+	}
+
+	keys, groups := t.groupRows(cols)
+	records := [][]string{header}
+	for _, key := range keys {
+		rows := groups[key]
+		rec := []string{}
+		for _, j := range cols {
+			rec = append(rec, cellValue(rows[0], j))
+		}
+		for _, a := range aggs {
+			rec = append(rec, aggregate(rows, a, t.ColumnType(a.Column)))
+		}
+		records = append(records, rec)
+	}
+
+	return t.derive(records, true), nil
+}
+
+// groupRows returns the data rows of each distinct combination of values of
+// the columns cols, keyed by the joined values. The keys are returned in the
+// order the groups are first seen.
+func (t *Table) groupRows(cols []int) ([]string, map[string][]*Row) {
+	keys := []string{}
+	groups := map[string][]*Row{}
+	for _, row := range t.rows {
+		if !row.IsData() {
+			continue
+		}
+		vs := make([]string, len(cols))
+		for k, j := range cols {
+			vs[k] = cellValue(row, j)
+		}
+		key := strings.Join(vs, "\x00")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+	return keys, groups
+}
+
+// derive returns a new table holding the records, with the options of t. The
+// first record is the header, if requested. The new table has no sections.
+func (t *Table) derive(records [][]string, header bool) *Table {
+	o := *t.opts
+	o.header, o.sectionReset = header, false
+	d := &Table{opts: &o, rows: []*Row{}}
+	d.sections = []*Section{d.newSection()}
+	d.Append(records...)
+	return d
+}
+
+// columnName returns the header name of column j, or its one-based number if
+// the table has no header or the name is empty.
+func (t *Table) columnName(j int) string {
+	if hdr := t.Header(); hdr != nil && j < hdr.NumColumns() && hdr.columns[j].cell != "" {
+		return hdr.columns[j].cell
+	}
+	return strconv.Itoa(j + 1)
+}
+
+// avgDecimals is the number of decimal places an average has beyond those of
+// its values.
+const avgDecimals = 4
+
+// aggregate returns the aggregate of the rows of a group. The type of the
+// column determines how values are compared by min and max. Sums are rounded
+// to the most decimal places of their values, and averages to a few more, so
+// that floating point error does not show.
+func aggregate(rows []*Row, a Aggregate, typ Type) string {
+	switch a.Func {
+	case AggCount:
+		return strconv.Itoa(len(rows))
+	case AggSum, AggAvg:
+		sum, n, dec := 0.0, 0, 0
+		for _, row := range rows {
+			if v, err := strconv.ParseFloat(sortValue(row, a.Column), 64); err == nil {
+				sum += v
+				n++
+				dec = math.Max(dec, decimals(v))
+			}
+		}
+		switch {
+		case n == 0:
+			return ""
+		case a.Func == AggAvg:
+			sum /= float64(n)
+			dec += avgDecimals
+		}
+		return roundFloat(sum, dec)
+	}
+
+	cmp := CompareLexical
+	switch {
+	case typ.IsNumeric():
+		cmp = CompareNumeric
+	case typ == TypeTime:
+		cmp = CompareDate
+	}
+	best := ""
+	for _, row := range rows {
+		v := sortValue(row, a.Column)
+		if v == "" {
+			continue
+		}
+		c := compareValues(v, best, cmp)
+		if best == "" || (a.Func == AggMin && c < 0) || (a.Func == AggMax && c > 0) {
+			best = v
+		}
+	}
+	return best
+}
+
+// cellValue returns the value of column j of the row, or an empty string if
+// the row is too short.
+func cellValue(row *Row, j int) string {
+	if j < row.NumColumns() {
+		return row.columns[j].cell
+	}
+	return ""
+}
+
+// decimals returns the number of decimal places of v.
+func decimals(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// roundFloat returns v rounded to dec decimal places, without trailing zeros.
+func roundFloat(v float64, dec int) string {
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'f', dec, 64), 64)
+	return strconv.FormatFloat(r, 'f', -1, 64)
+}
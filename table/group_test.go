@@ -0,0 +1,114 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseAggregates(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want []Aggregate
+		ok   bool
+	}{
+		{"count", []Aggregate{{Func: AggCount}}, true},
+		{"count,sum:3,avg:3,max:4", []Aggregate{{Func: AggCount}, {AggSum, 2}, {AggAvg, 2}, {AggMax, 3}}, true},
+		{"MIN:1", []Aggregate{{AggMin, 0}}, true},
+		{"sum", nil, false},
+		{"sum:0", nil, false},
+		{"count:1", nil, false},
+		{"median:1", nil, false},
+	} {
+		t.Run(fmt.Sprintf("ParseAggregates(%q)", tc.s), func(t *testing.T) {
+			got, err := ParseAggregates(tc.s)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if tc.ok && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("= %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	lines := []string{
+		"# files",
+		"dir name size",
+		"/bin sh 120",
+		"/etc passwd 2.5",
+		"/bin ls 80",
+		"",
+		"/etc hosts x",
+	}
+	for _, tc := range []struct {
+		desc   string
+		header bool
+		cols   []int
+		aggs   string
+		want   [][]string
+		ok     bool
+	}{
+		{"count", true, []int{0}, "count",
+			[][]string{{"dir", "count"}, {"/bin", "2"}, {"/etc", "2"}}, true},
+		{"sum avg", true, []int{0}, "sum:3,avg:3",
+			[][]string{{"dir", "sum(size)", "avg(size)"}, {"/bin", "200", "100"}, {"/etc", "2.5", "2.5"}}, true},
+		{"min max lexical", true, []int{0}, "min:2,max:3",
+			[][]string{{"dir", "min(name)", "max(size)"}, {"/bin", "ls", "80"}, {"/etc", "hosts", "x"}}, true},
+		{"no header", false, []int{0}, "count",
+			[][]string{{"1", "count"}, {"dir", "1"}, {"/bin", "2"}, {"/etc", "2"}}, true},
+		{"no groups", true, nil, "count,sum:3",
+			[][]string{{"count", "sum(size)"}, {"4", "202.5"}}, true},
+		{"out of range", true, []int{3}, "count", nil, false},
+	} {
+		t.Run(fmt.Sprintf("GroupBy() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, EnableComments(true), Header(tc.header), SectionReset(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			aggs, err := ParseAggregates(tc.aggs)
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			g, err := tbl.GroupBy(tc.cols, aggs...)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if !tc.ok {
+				return
+			}
+
+			got := [][]string{}
+			for _, row := range g.Rows() {
+				got = append(got, row.Values())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if g.Header() == nil || g.Header().Values()[0] != tc.want[0][0] {
+				t.Errorf("header = %v, want %q", g.Header(), tc.want[0])
+			}
+		})
+	}
+}
+
+func TestGroupBy_Fractions(t *testing.T) {
+	tbl, err := Split([]string{"a 0.1", "a 0.2", "b 1.2", "b 2.4", "c 1", "c 2"}, " ", -1)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	g, err := tbl.GroupBy([]int{0}, Aggregate{AggSum, 1}, Aggregate{AggAvg, 1})
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	got := [][]string{}
+	for _, row := range g.Rows() {
+		got = append(got, row.Values())
+	}
+	want := [][]string{{"1", "sum(2)", "avg(2)"}, {"a", "0.3", "0.15"}, {"b", "3.6", "1.8"}, {"c", "3", "1.5"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+}
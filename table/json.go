@@ -0,0 +1,170 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ReadJSON reads a JSON array into a table. The array holds either objects or
+// arrays, with each element becoming a row. See ReadNDJSON() for how the
+// elements are converted.
+func ReadJSON(r io.Reader, opts ...func(*options) error) (*Table, error) {
+	var elems []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&elems); err != nil {
+		return nil, fmt.Errorf("error decoding JSON array; %s", err)
+	}
+	return readJSON(elems, opts...)
+}
+
+// ReadNDJSON reads a stream of newline-delimited JSON values into a table. The
+// values are either objects or arrays, with each value becoming a row.
+//
+// Objects are keyed by column name, with the union of all keys forming a header
+// row. Keys appear in first-seen order, or sorted with the JSONSortKeys()
+// option. Nested objects are flattened, joining the keys with dots (e.g.
+// "owner.name"). Arrays hold the values of each column in order. Strings are
+// unquoted, null values are empty, and all other values (including nested
+// arrays) are kept as JSON.
+func ReadNDJSON(r io.Reader, opts ...func(*options) error) (*Table, error) {
+	elems := []json.RawMessage{}
+	dec := json.NewDecoder(r)
+	for {
+		var elem json.RawMessage
+		err := dec.Decode(&elem)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding JSON value %d; %s", len(elems)+1, err)
+		}
+		elems = append(elems, elem)
+	}
+	return readJSON(elems, opts...)
+}
+
+func readJSON(elems []json.RawMessage, opts ...func(*options) error) (*Table, error) {
+	tbl, err := NewTable(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating a table; %s", err)
+	}
+
+	keys := []string{}
+	seen := map[string]bool{}
+	objs := []map[string]string{}
+	arrs := [][]string{}
+	for i, elem := range elems {
+		switch jsonKind(elem) {
+		case '{':
+			obj := map[string]string{}
+			if err := flattenJSON(elem, "", obj, func(k string) {
+				if !seen[k] {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}); err != nil {
+				return nil, fmt.Errorf("element %d; %s", i+1, err)
+			}
+			objs = append(objs, obj)
+		case '[':
+			var vs []json.RawMessage
+			if err := json.Unmarshal(elem, &vs); err != nil {
+				return nil, fmt.Errorf("element %d; %s", i+1, err)
+			}
+			rec := make([]string, len(vs))
+			for j, v := range vs {
+				if rec[j], err = jsonCell(v); err != nil {
+					return nil, fmt.Errorf("element %d; %s", i+1, err)
+				}
+			}
+			arrs = append(arrs, rec)
+		default:
+			return nil, fmt.Errorf("element %d is not an object or array", i+1)
+		}
+		if len(objs) > 0 && len(arrs) > 0 {
+			return nil, fmt.Errorf("element %d; objects and arrays cannot be mixed", i+1)
+		}
+	}
+
+	if len(objs) > 0 {
+		if tbl.opts.jsonSortKeys {
+			sort.Strings(keys)
+		}
+		hdr := newRow(keys, false)
+		hdr.isHeader = true
+		tbl.appendRow(hdr)
+		for _, obj := range objs {
+			rec := make([]string, len(keys))
+			for j, k := range keys {
+				rec[j] = obj[k]
+			}
+			tbl.appendRow(newRow(rec, false))
+		}
+	}
+	for _, rec := range arrs {
+		tbl.appendRow(newRow(rec, false))
+	}
+	tbl.markHeader()
+	return tbl, nil
+}
+
+// jsonKind returns the first character of a JSON value, which identifies its
+// kind.
+func jsonKind(v json.RawMessage) byte {
+	v = bytes.TrimSpace(v)
+	if len(v) == 0 {
+		return 0
+	}
+	return v[0]
+}
+
+// flattenJSON stores the values of a JSON object in obj, keyed by their dotted
+// key path. The key func is called for each key, in the order seen.
+func flattenJSON(v json.RawMessage, prefix string, obj map[string]string, key func(string)) error {
+	dec := json.NewDecoder(bytes.NewReader(v))
+	if _, err := dec.Token(); err != nil { // Opening brace.
+		return err
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k := prefix + t.(string)
+
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		if jsonKind(elem) == '{' {
+			if err := flattenJSON(elem, k+".", obj, key); err != nil {
+				return err
+			}
+			continue
+		}
+		if obj[k], err = jsonCell(elem); err != nil {
+			return err
+		}
+		key(k)
+	}
+	return nil
+}
+
+// jsonCell converts a JSON value into cell data.
+func jsonCell(v json.RawMessage) (string, error) {
+	switch jsonKind(v) {
+	case '"':
+		var s string
+		err := json.Unmarshal(v, &s)
+		return s, err
+	case 'n':
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, v); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,85 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadJSON(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		data string
+		sort bool
+
+		values [][]string
+		header bool
+		ok     bool
+	}{
+		{"objects",
+			`[{"name": "root", "uid": 0}, {"name": "nobody", "uid": -2, "shell": null}]`, false,
+			[][]string{{"name", "uid", "shell"}, {"root", "0", ""}, {"nobody", "-2", ""}}, true, true},
+		{"sorted keys",
+			`[{"name": "root", "uid": 0, "gid": 0}]`, true,
+			[][]string{{"gid", "name", "uid"}, {"0", "root", "0"}}, true, true},
+		{"nested objects",
+			`[{"id": 1, "owner": {"name": "kate", "ids": [1, 2]}, "ok": true}]`, false,
+			[][]string{{"id", "owner.name", "owner.ids", "ok"}, {"1", "kate", "[1,2]", "true"}}, true, true},
+		{"arrays",
+			`[["a", 1.5], ["b", null, "c"]]`, false,
+			[][]string{{"a", "1.5"}, {"b", "", "c"}}, false, true},
+		{"empty", `[]`, false,
+			[][]string{}, false, true},
+
+		// Error cases.
+		{desc: "not an array", data: `{"a": 1}`},
+		{desc: "scalar element", data: `[1]`},
+		{desc: "mixed elements", data: `[{"a": 1}, [1]]`},
+		{desc: "invalid", data: `[{"a": }]`},
+	} {
+		t.Run(fmt.Sprintf("ReadJSON() %s", tc.desc), func(t *testing.T) {
+			tbl, err := ReadJSON(strings.NewReader(tc.data), JSONSortKeys(tc.sort))
+			if err == nil && !tc.ok {
+				t.Fatal("expected an error")
+			}
+			if err != nil {
+				if tc.ok {
+					t.Fatalf("unexpected error; %s", err)
+				}
+				return
+			}
+
+			values := [][]string{}
+			for _, row := range tbl.Rows() {
+				values = append(values, row.Values())
+			}
+			if got, want := values, tc.values; !reflect.DeepEqual(got, want) {
+				t.Errorf("values = %q, want %q", got, want)
+			}
+			if got, want := tbl.Header() != nil, tc.header; got != want {
+				t.Errorf("Header() != nil is %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	data := "{\"a\": 1}\n{\"b\": \"x\", \"a\": 2}\n"
+	tbl, err := ReadNDJSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	values := [][]string{}
+	for _, row := range tbl.Rows() {
+		values = append(values, row.Values())
+	}
+	if got, want := values, [][]string{{"a", "b"}, {"1", ""}, {"2", "x"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %q, want %q", got, want)
+	}
+
+	if _, err := ReadNDJSON(strings.NewReader("{\"a\": 1}\n{")); err == nil {
+		t.Error("expected an error for a truncated stream")
+	}
+}
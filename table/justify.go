@@ -0,0 +1,64 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Justification describes how a value is aligned within a column.
+type Justification int
+
+const (
+	JustifyLeft Justification = iota
+	JustifyRight
+	JustifyCenter
+)
+
+// ParseJustification converts a string (e.g. "left" or "l") into a
+// Justification.
+func ParseJustification(s string) (Justification, error) {
+	switch strings.ToLower(s) {
+	case "l", "left":
+		return JustifyLeft, nil
+	case "r", "right":
+		return JustifyRight, nil
+	case "c", "center":
+		return JustifyCenter, nil
+	}
+	return JustifyLeft, fmt.Errorf("invalid justification %q", s)
+}
+
+// Padding returns the number of spaces needed to the left and right of a value
+// of the given display width to justify it within width.
+func (j Justification) Padding(length, width int) (left, right int) {
+	pad := width - length
+	if pad <= 0 {
+		return 0, 0
+	}
+	switch j {
+	case JustifyRight:
+		return pad, 0
+	case JustifyCenter:
+		return pad / 2, pad - pad/2
+	}
+	return 0, pad
+}
+
+// Pad the value with spaces so that it is justified within width.
+func (j Justification) Pad(s string, width int) string {
+	left, right := j.Padding(Width(s), width)
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", right)
+}
+
+// String implements fmt.Stringer.
+func (j Justification) String() string {
+	switch j {
+	case JustifyLeft:
+		return "left"
+	case JustifyRight:
+		return "right"
+	case JustifyCenter:
+		return "center"
+	}
+	return fmt.Sprintf("Justification(%d)", int(j))
+}
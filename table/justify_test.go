@@ -0,0 +1,63 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestJustificationPad(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		j     Justification
+		s     string
+		width int
+		want  string
+	}{
+		{"left", JustifyLeft, "ab", 5, "ab   "},
+		{"right", JustifyRight, "ab", 5, "   ab"},
+		{"center", JustifyCenter, "ab", 5, " ab  "},
+		{"center even", JustifyCenter, "ab", 6, "  ab  "},
+		{"too narrow", JustifyRight, "abc", 2, "abc"},
+	} {
+		t.Run(fmt.Sprintf("Pad() %s", tc.desc), func(t *testing.T) {
+			if got, want := tc.j.Pad(tc.s, tc.width), tc.want; got != want {
+				t.Errorf("Pad() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestParseJustification(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want Justification
+		ok   bool
+	}{
+		{"l", JustifyLeft, true},
+		{"Right", JustifyRight, true},
+		{"c", JustifyCenter, true},
+		{"middle", JustifyLeft, false},
+	} {
+		t.Run(fmt.Sprintf("ParseJustification() %s", tc.s), func(t *testing.T) {
+			got, err := ParseJustification(tc.s)
+			if (err == nil) != tc.ok {
+				t.Fatalf("unexpected error state; %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ParseJustification() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTableJustification(t *testing.T) {
+	tbl, err := NewTable(Justify(JustifyCenter), ColumnJustifications([]Justification{JustifyLeft, JustifyRight}))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+	for i, want := range []Justification{JustifyLeft, JustifyRight, JustifyCenter} {
+		if got := tbl.Justification(i); got != want {
+			t.Errorf("column #%d: Justification() = %v, want %v", i, got, want)
+		}
+	}
+}
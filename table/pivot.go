@@ -0,0 +1,80 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Transpose returns a new table with the rows and columns of t swapped, so that
+// the header and data rows of t become columns. If t has a header, the new
+// table has one too, formed from the first column of t. Comment, blank and
+// footer rows are dropped.
+func (t *Table) Transpose() *Table {
+	rows := []*Row{}
+	for _, row := range t.rows {
+		if row.IsHeader() || row.IsData() {
+			rows = append(rows, row)
+		}
+	}
+
+	records := make([][]string, len(t.colSizes))
+	for j := range records {
+		records[j] = make([]string, len(rows))
+		for i, row := range rows {
+			records[j][i] = cellValue(row, j)
+		}
+	}
+	return t.derive(records, t.Header() != nil)
+}
+
+// Pivot returns a new table with one row per distinct combination of values of
+// the index columns, and one column per distinct value of the zero-based pivot
+// column. Each cell holds the aggregate of the rows with the values of its row
+// and column, and is empty if there are none. Values are ordered as they are
+// first seen. The new table has a header, named after the index columns and
+// the pivot column values.
+func (t *Table) Pivot(index []int, column int, agg Aggregate) (*Table, error) {
+	numCols := len(t.colSizes)
+	for _, j := range append([]int{column}, index...) {
+		if j < 0 || j >= numCols {
+			return nil, fmt.Errorf("pivot column %d is outside the %d columns of the table", j+1, numCols)
+		}
+	}
+	if agg.Func != AggCount && (agg.Column < 0 || agg.Column >= numCols) {
+		return nil, fmt.Errorf("%s column %d is outside the %d columns of the table", agg.Func, agg.Column+1, numCols)
+	}
+
+	keys, groups := t.groupRows(index)
+	values, _ := t.groupRows([]int{column})
+
+	header := []string{}
+	for _, j := range index {
+		header = append(header, t.columnName(j))
+	}
+	header = append(header, values...)
+
+	typ := t.ColumnType(agg.Column)
+	records := [][]string{header}
+	for _, key := range keys {
+		rows := groups[key]
+		rec := []string{}
+		if len(index) > 0 {
+			rec = append(rec, strings.Split(key, "\x00")...)
+		}
+		for _, v := range values {
+			matched := []*Row{}
+			for _, row := range rows {
+				if cellValue(row, column) == v {
+					matched = append(matched, row)
+				}
+			}
+			cell := ""
+			if len(matched) > 0 {
+				cell = aggregate(matched, agg, typ)
+			}
+			rec = append(rec, cell)
+		}
+		records = append(records, rec)
+	}
+	return t.derive(records, true), nil
+}
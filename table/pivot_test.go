@@ -0,0 +1,94 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTranspose(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		lines  []string
+		header bool
+		want   [][]string
+	}{
+		{"header", []string{"# users", "name uid shell", "root 0 /bin/sh", "nobody -2"}, true,
+			[][]string{{"name", "root", "nobody"}, {"uid", "0", "-2"}, {"shell", "/bin/sh", ""}}},
+		{"key value", []string{"host example.com", "port 80"}, false,
+			[][]string{{"host", "port"}, {"example.com", "80"}}},
+	} {
+		t.Run(fmt.Sprintf("Transpose() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(tc.lines, " ", -1, EnableComments(true), Header(tc.header))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			tr := tbl.Transpose()
+
+			got := [][]string{}
+			for _, row := range tr.Rows() {
+				got = append(got, row.Values())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if got, want := tr.Header() != nil, tc.header; got != want {
+				t.Errorf("has header = %v, want %v", got, want)
+			}
+			if got, want := len(tr.ColSizes()), len(tc.want[0]); got != want {
+				t.Errorf("columns = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPivot(t *testing.T) {
+	lines := []string{
+		"region quarter sales",
+		"east q1 10",
+		"west q1 5",
+		"east q2 7",
+		"east q1 3",
+	}
+	for _, tc := range []struct {
+		desc  string
+		index []int
+		col   int
+		agg   Aggregate
+		want  [][]string
+		ok    bool
+	}{
+		{"sum", []int{0}, 1, Aggregate{AggSum, 2},
+			[][]string{{"region", "q1", "q2"}, {"east", "13", "7"}, {"west", "5", ""}}, true},
+		{"count", []int{0}, 1, Aggregate{Func: AggCount},
+			[][]string{{"region", "q1", "q2"}, {"east", "2", "1"}, {"west", "1", ""}}, true},
+		{"no index", nil, 0, Aggregate{AggMax, 2},
+			[][]string{{"east", "west"}, {"10", "5"}}, true},
+		{"out of range", []int{0}, 3, Aggregate{Func: AggCount}, nil, false},
+	} {
+		t.Run(fmt.Sprintf("Pivot() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, Header(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			p, err := tbl.Pivot(tc.index, tc.col, tc.agg)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if !tc.ok {
+				return
+			}
+
+			got := [][]string{}
+			for _, row := range p.Rows() {
+				got = append(got, row.Values())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if p.Header() == nil {
+				t.Errorf("Header() = nil")
+			}
+		})
+	}
+}
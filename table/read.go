@@ -0,0 +1,135 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// InputFormat describes the format of the data read into a table.
+type InputFormat int
+
+const (
+	InputText   InputFormat = iota // Lines of text split on a field separator.
+	InputRegexp                    // Lines of text split on a regular expression.
+	InputAwk                       // Lines of text split on runs of whitespace.
+	InputFixed                     // Lines of text split into fixed-width columns.
+	InputCSV                       // RFC 4180 CSV records.
+	InputJSON                      // A JSON array of objects or arrays.
+	InputNDJSON                    // Newline-delimited JSON objects or arrays.
+)
+
+// InputFormats lists the supported input formats.
+var InputFormats = []InputFormat{
+	InputText,
+	InputRegexp,
+	InputAwk,
+	InputFixed,
+	InputCSV,
+	InputJSON,
+	InputNDJSON,
+}
+
+// ParseInputFormat converts a string (e.g. "csv") into an InputFormat.
+func ParseInputFormat(s string) (InputFormat, error) {
+	for _, f := range InputFormats {
+		if strings.ToLower(s) == f.String() {
+			return f, nil
+		}
+	}
+	return InputText, fmt.Errorf("invalid input format %q", s)
+}
+
+// String implements fmt.Stringer.
+func (f InputFormat) String() string {
+	switch f {
+	case InputText:
+		return "text"
+	case InputRegexp:
+		return "regexp"
+	case InputAwk:
+		return "awk"
+	case InputFixed:
+		return "fixed"
+	case InputCSV:
+		return "csv"
+	case InputJSON:
+		return "json"
+	case InputNDJSON:
+		return "ndjson"
+	}
+	return fmt.Sprintf("InputFormat(%d)", int(f))
+}
+
+// Read data in the input format chosen with the Input() option into a table.
+// The field separator `ifs` and count `n` are interpreted as for Split(). For
+// the regexp format, `ifs` is a regular expression. The awk and fixed formats
+// ignore it; fixed-width columns start at the positions given with the
+// ColumnPositions() option, or are detected from the data. The JSON formats
+// ignore both `ifs` and `n`.
+func Read(r io.Reader, ifs string, n int, opts ...func(*options) error) (*Table, error) {
+	o, err := NewTable(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error instantiating a table; %s", err)
+	}
+
+	switch o.opts.input {
+	case InputCSV:
+		delim, size := utf8.DecodeRuneInString(ifs)
+		if size != len(ifs) {
+			return nil, fmt.Errorf("CSV field separator %q must be a single character", ifs)
+		}
+		return ReadCSV(r, delim, n, opts...)
+	case InputJSON:
+		return ReadJSON(r, opts...)
+	case InputNDJSON:
+		return ReadNDJSON(r, opts...)
+	}
+
+	lines, err := readLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var splitter Splitter
+	switch o.opts.input {
+	case InputRegexp:
+		if splitter, err = NewRegexpSplitter(ifs); err != nil {
+			return nil, err
+		}
+	case InputAwk:
+		splitter = NewWhitespaceSplitter()
+	case InputFixed:
+		positions := o.opts.positions
+		if positions == nil {
+			data := []string{}
+			for _, line := range lines {
+				if !(o.opts.enableComments && strings.HasPrefix(line, o.opts.commentPrefix)) {
+					data = append(data, line)
+				}
+			}
+			positions = DetectColumns(data)
+		}
+		if splitter, err = NewFixedSplitter(positions); err != nil {
+			return nil, err
+		}
+	default:
+		splitter = NewLiteralSplitter(ifs)
+	}
+	return SplitWith(lines, splitter, n, opts...)
+}
+
+// readLines returns all lines read from r.
+func readLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("error reading lines; %s", err)
+	}
+	return lines, nil
+}
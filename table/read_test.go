@@ -0,0 +1,44 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		data   string
+		ifs    string
+		format InputFormat
+		values [][]string
+	}{
+		{"text", "a,b \"c d\"", " ", InputText,
+			[][]string{{"a,b", `"c`, `d"`}}},
+		{"regexp", "a1b22c", "[0-9]+", InputRegexp,
+			[][]string{{"a", "b", "c"}}},
+		{"awk", " a\t b  c ", "ignored", InputAwk,
+			[][]string{{"a", "b", "c"}}},
+		{"fixed", "USER  PID\nroot    1\nsys a  12", "ignored", InputFixed,
+			[][]string{{"USER", "PID"}, {"root", "1"}, {"sys a", "12"}}},
+		{"csv", "a,b \"c d\"", ",", InputCSV,
+			[][]string{{"a", `b "c d"`}}},
+	} {
+		t.Run(fmt.Sprintf("Read() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Read(strings.NewReader(tc.data), tc.ifs, -1, Input(tc.format), CSVLazyQuotes(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			values := [][]string{}
+			for _, row := range tbl.Rows() {
+				values = append(values, row.Values())
+			}
+			if got, want := values, tc.values; !reflect.DeepEqual(got, want) {
+				t.Errorf("values = %q, want %q", got, want)
+			}
+		})
+	}
+}
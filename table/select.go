@@ -0,0 +1,145 @@
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ColumnSelector selects one or more columns of a table, by header name or by
+// a range of one-based indexes. Negative indexes count back from the last
+// column, so that -1 is the last column.
+type ColumnSelector struct {
+	Name   string // Header name of the column. If empty, From and To are used.
+	From   int    // Index of the first column of the range.
+	To     int    // Index of the last column of the range.
+	Rename string // New header name of a single selected column, if not empty.
+}
+
+// columnRangeRE matches a column index, or a range of column indexes. An open
+// range (e.g. "3-") extends to the last column.
+var columnRangeRE = regexp.MustCompile(`^(-?[0-9]+)$|^(-?[0-9]+)-(-?[0-9]+)?$`)
+
+// ParseColumnSelectors parses a comma-separated list of column selectors (e.g.
+// "1,5,7", "name,shell" or "2-4,-1"). Each selector is a one-based column
+// index, a range of indexes, or a header name, and may be followed by
+// "=name" to rename a single column.
+func ParseColumnSelectors(s string) ([]ColumnSelector, error) {
+	sels := []ColumnSelector{}
+	for _, f := range strings.Split(s, ",") {
+		sel := ColumnSelector{}
+		if i := strings.Index(f, "="); i >= 0 {
+			f, sel.Rename = f[:i], strings.TrimSpace(f[i+1:])
+		}
+		f = strings.TrimSpace(f)
+		m := columnRangeRE.FindStringSubmatch(f)
+		switch {
+		case f == "":
+			return nil, fmt.Errorf("empty column selector in %q", s)
+		case m == nil:
+			sel.Name = f
+		case m[1] != "":
+			sel.From, _ = strconv.Atoi(m[1])
+			sel.To = sel.From
+		default:
+			sel.From, _ = strconv.Atoi(m[2])
+			sel.To = -1
+			if m[3] != "" {
+				sel.To, _ = strconv.Atoi(m[3])
+			}
+			if sel.Rename != "" {
+				return nil, fmt.Errorf("column range %q cannot be renamed", f)
+			}
+		}
+		if sel.Name == "" && (sel.From == 0 || sel.To == 0) {
+			return nil, fmt.Errorf("invalid column selector %q; indexes start at 1", f)
+		}
+		sels = append(sels, sel)
+	}
+	return sels, nil
+}
+
+// Select replaces the columns of the table with the selected columns, in the
+// order given. Columns may be selected more than once. Comment rows are kept as
+// they are.
+func (t *Table) Select(sels ...ColumnSelector) error {
+	numCols := len(t.colSizes)
+	hdr := t.Header()
+	cols, names := []int{}, map[int]string{}
+	for _, sel := range sels {
+		if sel.Rename != "" && hdr == nil {
+			return fmt.Errorf("renaming column to %q requires a header", sel.Rename)
+		}
+
+		if sel.Name != "" {
+			if hdr == nil {
+				return fmt.Errorf("selecting column %q by name requires a header", sel.Name)
+			}
+			col := -1
+			for j, c := range hdr.Columns() {
+				if strings.TrimSpace(StripANSI(c.Value())) == sel.Name {
+					col = j
+					break
+				}
+			}
+			if col < 0 {
+				return fmt.Errorf("unknown column %q", sel.Name)
+			}
+			if sel.Rename != "" {
+				names[len(cols)] = sel.Rename
+			}
+			cols = append(cols, col)
+			continue
+		}
+
+		from, to := columnIndex(sel.From, numCols), columnIndex(sel.To, numCols)
+		if from < 0 || to < 0 {
+			return fmt.Errorf("column range %d-%d is outside the %d columns of the table", sel.From, sel.To, numCols)
+		}
+		if sel.Rename != "" {
+			names[len(cols)] = sel.Rename
+		}
+		step := 1
+		if from > to {
+			step = -1
+		}
+		for j := from; ; j += step {
+			cols = append(cols, j)
+			if j == to {
+				break
+			}
+		}
+	}
+
+	rows := make([]*Row, len(t.rows))
+	for i, row := range t.rows {
+		if row.IsComment() {
+			rows[i] = row
+			continue
+		}
+		vs := make([]string, len(cols))
+		for k, j := range cols {
+			vs[k] = cellValue(row, j)
+			if name, ok := names[k]; ok && row.IsHeader() {
+				vs[k] = name
+			}
+		}
+		rows[i] = newRow(vs, false)
+	}
+	t.replaceRows(rows)
+	return nil
+}
+
+// columnIndex returns the zero-based index of the one-based column index i,
+// where negative indexes count back from the last column. It returns -1 if the
+// column does not exist.
+func columnIndex(i, numCols int) int {
+	if i < 0 {
+		i += numCols + 1
+	}
+	if i < 1 || i > numCols {
+		return -1
+	}
+	return i - 1
+}
@@ -0,0 +1,93 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseColumnSelectors(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want []ColumnSelector
+		ok   bool
+	}{
+		{"1,5,7", []ColumnSelector{{From: 1, To: 1}, {From: 5, To: 5}, {From: 7, To: 7}}, true},
+		{"2-4,-1", []ColumnSelector{{From: 2, To: 4}, {From: -1, To: -1}}, true},
+		{"3-", []ColumnSelector{{From: 3, To: -1}}, true},
+		{"2--2", []ColumnSelector{{From: 2, To: -2}}, true},
+		{"name,shell=login", []ColumnSelector{{Name: "name"}, {Name: "shell", Rename: "login"}}, true},
+		{"1=id", []ColumnSelector{{From: 1, To: 1, Rename: "id"}}, true},
+		{"name = n", []ColumnSelector{{Name: "name", Rename: "n"}}, true},
+		{"1-2=x", nil, false},
+		{"0", nil, false},
+		{"1,,2", nil, false},
+	} {
+		t.Run(fmt.Sprintf("ParseColumnSelectors(%q)", tc.s), func(t *testing.T) {
+			got, err := ParseColumnSelectors(tc.s)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if tc.ok && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("= %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	lines := []string{"# users", "name uid shell", "root 0 /bin/sh", "nobody -2"}
+	for _, tc := range []struct {
+		desc   string
+		sels   string
+		header bool
+		want   [][]string
+		ok     bool
+	}{
+		{"indexes", "3,1", true,
+			[][]string{{"# users"}, {"shell", "name"}, {"/bin/sh", "root"}, {"", "nobody"}}, true},
+		{"range", "2-", true,
+			[][]string{{"# users"}, {"uid", "shell"}, {"0", "/bin/sh"}, {"-2", ""}}, true},
+		{"reversed range", "-1-1", true,
+			[][]string{{"# users"}, {"shell", "uid", "name"}, {"/bin/sh", "0", "root"}, {"", "-2", "nobody"}}, true},
+		{"names", "shell=login,name", true,
+			[][]string{{"# users"}, {"login", "name"}, {"/bin/sh", "root"}, {"", "nobody"}}, true},
+		{"negative", "-1", false,
+			[][]string{{"# users"}, {"shell"}, {"/bin/sh"}, {""}}, true},
+		{"name without header", "name", false, nil, false},
+		{"unknown name", "home", true, nil, false},
+		{"out of range", "4", true, nil, false},
+	} {
+		t.Run(fmt.Sprintf("Select() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, EnableComments(true), Header(tc.header))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			sels, err := ParseColumnSelectors(tc.sels)
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			err = tbl.Select(sels...)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if !tc.ok {
+				return
+			}
+
+			got := [][]string{}
+			for _, row := range tbl.Rows() {
+				got = append(got, row.Values())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if got, want := len(tbl.ColSizes()), len(tc.want[1]); got != want {
+				t.Errorf("columns = %d, want %d", got, want)
+			}
+			if tc.header && !tbl.Rows()[1].IsHeader() {
+				t.Errorf("header row lost")
+			}
+		})
+	}
+}
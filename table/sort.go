@@ -0,0 +1,222 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Comparator describes how the values of a sort key are compared.
+type Comparator int
+
+const (
+	CompareLexical Comparator = iota // Byte-wise string comparison.
+	CompareNumeric                   // Decimal numbers.
+	CompareNatural                   // Strings with embedded numbers, e.g. "v1.10".
+	CompareDate                      // Dates and times.
+)
+
+// SortKey describes a column to sort rows by.
+type SortKey struct {
+	Column     int // Zero-based column index.
+	Descending bool
+	Comparator Comparator
+}
+
+// ParseSortKeys parses a comma-separated list of sort keys (e.g. "3n,-1"). Each
+// key is a one-based column number, preceded by '-' for descending order, and
+// optionally followed by a comparator: l (lexical, the default), n (numeric),
+// v (natural) or d (date).
+func ParseSortKeys(s string) ([]SortKey, error) {
+	keys := []SortKey{}
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		k := SortKey{}
+		if strings.HasPrefix(f, "-") {
+			k.Descending = true
+			f = f[1:]
+		}
+		if i := strings.IndexFunc(f, func(r rune) bool { return !unicode.IsDigit(r) }); i >= 0 {
+			switch f[i:] {
+			case "l":
+				k.Comparator = CompareLexical
+			case "n":
+				k.Comparator = CompareNumeric
+			case "v":
+				k.Comparator = CompareNatural
+			case "d":
+				k.Comparator = CompareDate
+			default:
+				return nil, fmt.Errorf("invalid sort key %q; unknown comparator %q", f, f[i:])
+			}
+			f = f[:i]
+		}
+		col, err := strconv.Atoi(f)
+		if err != nil || col < 1 || col > MAX_COLS {
+			return nil, fmt.Errorf("invalid sort key column %q", f)
+		}
+		k.Column = col - 1
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Sort sorts the data rows of the table by the keys, with earlier keys taking
+// precedence. The sort is stable. Comment, header and blank rows keep their
+// positions, and data rows are only sorted within their section.
+func (t *Table) Sort(keys ...SortKey) error {
+	numCols := len(t.colSizes)
+	for _, k := range keys {
+		if k.Column < 0 || k.Column >= numCols {
+			return fmt.Errorf("sort column %d is outside the %d columns of the table", k.Column+1, numCols)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pos := map[*Row]int{}
+	for i, row := range t.rows {
+		pos[row] = i
+	}
+	for _, sec := range t.sections {
+		idx, rows := []int{}, []*Row{}
+		for i, row := range sec.rows {
+			if !row.IsData() {
+				continue
+			}
+			idx = append(idx, i)
+			rows = append(rows, row)
+		}
+		sort.SliceStable(rows, func(a, b int) bool { return compareRows(rows[a], rows[b], keys) < 0 })
+
+		targets := make([]int, len(idx))
+		for i, j := range idx {
+			targets[i] = pos[sec.rows[j]]
+		}
+		for i, row := range rows {
+			sec.rows[idx[i]] = row
+			t.rows[targets[i]] = row
+		}
+	}
+	return nil
+}
+
+// compareRows compares two rows by the keys. It returns a negative number if a
+// sorts before b, a positive number if a sorts after b, and zero otherwise.
+func compareRows(a, b *Row, keys []SortKey) int {
+	for _, k := range keys {
+		c := compareValues(sortValue(a, k.Column), sortValue(b, k.Column), k.Comparator)
+		if k.Descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// sortValue returns the value of column col of the row, stripped of ANSI
+// escape sequences and surrounding whitespace.
+func sortValue(row *Row, col int) string {
+	if col >= row.NumColumns() {
+		return ""
+	}
+	return strings.TrimSpace(StripANSI(row.columns[col].cell))
+}
+
+// compareValues compares two values with a comparator. Values that cannot be
+// parsed by the numeric or date comparators sort after those that can.
+func compareValues(a, b string, c Comparator) int {
+	switch c {
+	case CompareNumeric:
+		x, errA := strconv.ParseFloat(a, 64)
+		y, errB := strconv.ParseFloat(b, 64)
+		switch {
+		case errA == nil && errB == nil:
+			return compareFloats(x, y)
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		}
+	case CompareNatural:
+		return compareNatural(a, b)
+	case CompareDate:
+		x, okA := parseTime(a)
+		y, okB := parseTime(b)
+		switch {
+		case okA && okB && x.Before(y):
+			return -1
+		case okA && okB && x.After(y):
+			return 1
+		case okA && okB:
+			return 0
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// compareFloats compares two numbers.
+func compareFloats(x, y float64) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// compareNatural compares two strings, treating runs of digits as numbers.
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ca, cb := naturalChunk(a), naturalChunk(b)
+		a, b = a[len(ca):], b[len(cb):]
+		if isDigit(ca[0]) && isDigit(cb[0]) {
+			na, nb := strings.TrimLeft(ca, "0"), strings.TrimLeft(cb, "0")
+			if len(na) != len(nb) {
+				return len(na) - len(nb)
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(ca, cb); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// naturalChunk returns the leading run of digits, or of non-digits, of s.
+func naturalChunk(s string) string {
+	digit := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digit {
+		i++
+	}
+	return s[:i]
+}
+
+// isDigit returns true if b is an ASCII digit.
+func isDigit(b byte) bool { return '0' <= b && b <= '9' }
+
+// parseTime parses a date/time value in any of the supported layouts.
+func parseTime(s string) (time.Time, bool) {
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
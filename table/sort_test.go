@@ -0,0 +1,89 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseSortKeys(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want []SortKey
+		ok   bool
+	}{
+		{"1", []SortKey{{Column: 0}}, true},
+		{"3n,-1", []SortKey{{Column: 2, Comparator: CompareNumeric}, {Column: 0, Descending: true}}, true},
+		{"-2v, 4d", []SortKey{{Column: 1, Descending: true, Comparator: CompareNatural}, {Column: 3, Comparator: CompareDate}}, true},
+		{"0", nil, false},
+		{"1x", nil, false},
+		{"", nil, false},
+	} {
+		t.Run(fmt.Sprintf("ParseSortKeys(%q)", tc.s), func(t *testing.T) {
+			got, err := ParseSortKeys(tc.s)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if tc.ok && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("= %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	lines := []string{
+		"name version size date",
+		"# comment",
+		"b v1.10 10 2024-03-01",
+		"a v1.9 9 2024-01-15",
+		"c v1.10 100 2023-12-31",
+		"",
+		"e v2 1 2024-01-01",
+		"d v10 2 2022-01-01",
+	}
+	for _, tc := range []struct {
+		desc string
+		keys string
+		want []string // First column of each row.
+		ok   bool
+	}{
+		{"lexical", "1", []string{"name", "# comment", "a", "b", "c", "", "d", "e"}, true},
+		{"lexical descending", "-1", []string{"name", "# comment", "c", "b", "a", "", "e", "d"}, true},
+		{"lexical numbers", "3", []string{"name", "# comment", "b", "c", "a", "", "e", "d"}, true},
+		{"numeric", "3n", []string{"name", "# comment", "a", "b", "c", "", "e", "d"}, true},
+		{"natural", "2v", []string{"name", "# comment", "a", "b", "c", "", "e", "d"}, true},
+		{"stable", "2v,-3n", []string{"name", "# comment", "a", "c", "b", "", "e", "d"}, true},
+		{"date", "4d", []string{"name", "# comment", "c", "a", "b", "", "d", "e"}, true},
+		{"outside", "5", nil, false},
+	} {
+		t.Run(fmt.Sprintf("Sort() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(lines, " ", -1, EnableComments(true), Header(true), SectionReset(true))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			keys, err := ParseSortKeys(tc.keys)
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+			err = tbl.Sort(keys...)
+			if ok := err == nil; ok != tc.ok {
+				t.Fatalf("err = %v, want ok %v", err, tc.ok)
+			}
+			if !tc.ok {
+				return
+			}
+
+			got := []string{}
+			for _, row := range tbl.Rows() {
+				got = append(got, row.Values()[0])
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("rows = %q, want %q", got, tc.want)
+			}
+			if got, want := tbl.Sections()[1].Rows()[0].Values()[0], tc.want[6]; got != want {
+				t.Errorf("section row = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package table
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	kstrings "github.com/kward/golib/strings"
+)
+
+// Splitter splits a line of text into columns. The count `n` is interpreted as
+// for Split().
+type Splitter interface {
+	Split(line string, n int) []string
+}
+
+// LiteralSplitter splits lines on runs of a literal separator.
+type LiteralSplitter struct {
+	sep string
+}
+
+// Ensure the Splitter interface is implemented.
+var _ Splitter = new(LiteralSplitter)
+
+// NewLiteralSplitter returns a Splitter for the literal separator sep.
+func NewLiteralSplitter(sep string) *LiteralSplitter {
+	return &LiteralSplitter{sep: sep}
+}
+
+// Split implements the Splitter interface.
+func (s *LiteralSplitter) Split(line string, n int) []string {
+	return kstrings.SplitNMerged(line, s.sep, n)
+}
+
+// RegexpSplitter splits lines on matches of a regular expression.
+type RegexpSplitter struct {
+	re *regexp.Regexp
+}
+
+// Ensure the Splitter interface is implemented.
+var _ Splitter = new(RegexpSplitter)
+
+// NewRegexpSplitter returns a Splitter for the regular expression expr.
+func NewRegexpSplitter(expr string) (*RegexpSplitter, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid separator regexp; %s", err)
+	}
+	return &RegexpSplitter{re: re}, nil
+}
+
+// Split implements the Splitter interface.
+func (s *RegexpSplitter) Split(line string, n int) []string {
+	return s.re.Split(line, n)
+}
+
+// WhitespaceSplitter splits lines on runs of whitespace, ignoring leading and
+// trailing whitespace, similar to awk.
+type WhitespaceSplitter struct{}
+
+// Ensure the Splitter interface is implemented.
+var _ Splitter = new(WhitespaceSplitter)
+
+// NewWhitespaceSplitter returns a Splitter for runs of whitespace.
+func NewWhitespaceSplitter() *WhitespaceSplitter {
+	return &WhitespaceSplitter{}
+}
+
+// Split implements the Splitter interface.
+func (s *WhitespaceSplitter) Split(line string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return []string{""}
+	}
+	if n < 0 {
+		return strings.Fields(line)
+	}
+
+	cols := []string{}
+	for len(cols) < n-1 {
+		i := strings.IndexFunc(line, unicode.IsSpace)
+		if i < 0 {
+			break
+		}
+		cols = append(cols, line[:i])
+		line = strings.TrimLeftFunc(line[i:], unicode.IsSpace)
+	}
+	return append(cols, line)
+}
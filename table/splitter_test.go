@@ -0,0 +1,44 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSplitters(t *testing.T) {
+	re, err := NewRegexpSplitter(`\s*[,;]\s*`)
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		splitter Splitter
+		line     string
+		n        int
+		want     []string
+	}{
+		{"literal", NewLiteralSplitter(":"), "a::b:c", -1, []string{"a", "b", "c"}},
+		{"literal remainder", NewLiteralSplitter(":"), "a:b:c", 2, []string{"a", "b:c"}},
+		{"regexp", re, "a , b;c", -1, []string{"a", "b", "c"}},
+		{"regexp remainder", re, "a , b;c", 2, []string{"a", "b;c"}},
+		{"whitespace", NewWhitespaceSplitter(), "  a \t b  c ", -1, []string{"a", "b", "c"}},
+		{"whitespace remainder", NewWhitespaceSplitter(), "  a \t b  c ", 2, []string{"a", "b  c"}},
+		{"whitespace one column", NewWhitespaceSplitter(), " a b ", 1, []string{"a b"}},
+		{"whitespace short", NewWhitespaceSplitter(), "a", 3, []string{"a"}},
+		{"whitespace empty", NewWhitespaceSplitter(), "   ", -1, []string{""}},
+	} {
+		t.Run(fmt.Sprintf("Split() %s", tc.desc), func(t *testing.T) {
+			if got, want := tc.splitter.Split(tc.line, tc.n), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("Split(%q, %d) = %q, want %q", tc.line, tc.n, got, want)
+			}
+		})
+	}
+}
+
+func TestNewRegexpSplitter_Invalid(t *testing.T) {
+	if _, err := NewRegexpSplitter("("); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -1,9 +1,8 @@
 /*
 Package table provides functionality for holding and describing tabular data.
 
-TODO(2020-03-01) Add support for justification.
-- The table should have a default justification for each column.
-- Each column should be able to override the default table justification.
+Each column of a table is justified according to the table default, which each
+column is able to override.
 */
 package table
 
@@ -13,7 +12,6 @@ import (
 	"strings"
 
 	"github.com/kward/golib/math"
-	kstrings "github.com/kward/golib/strings"
 )
 
 const MAX_COLS = 100
@@ -22,7 +20,10 @@ const MAX_COLS = 100
 type Row struct {
 	columns   []*Column // Columnar data of the row.
 	sizes     []int     // Sizes of the columns.
+	raws      []string  // Values before formatting; nil if never formatted.
 	isComment bool
+	isHeader  bool
+	isFooter  bool
 }
 
 // NewRow instantiates a new row. If the row is a comment, there can be only one
@@ -39,9 +40,9 @@ func newRow(records []string, isComment bool) *Row {
 	sizes := []int{}
 	for _, r := range records {
 		cols = append(cols, &Column{cell: r})
-		sizes = append(sizes, len(r))
+		sizes = append(sizes, BlockWidth(r))
 	}
-	return &Row{cols, sizes, isComment}
+	return &Row{columns: cols, sizes: sizes, isComment: isComment}
 }
 
 // Values returns the cell data for the row.
@@ -53,6 +54,23 @@ func (r *Row) Values() []string {
 	return vs
 }
 
+// RawValues returns the cell data for the row as it was before any number
+// formatting.
+func (r *Row) RawValues() []string {
+	if r.raws == nil {
+		return r.Values()
+	}
+	return append([]string{}, r.raws...)
+}
+
+// rawValue returns the value of column j before any number formatting.
+func (r *Row) rawValue(j int) string {
+	if r.raws != nil {
+		return r.raws[j]
+	}
+	return r.columns[j].cell
+}
+
 // Columns held in the row.
 func (r *Row) Columns() []*Column { return r.columns }
 
@@ -65,6 +83,28 @@ func (r *Row) Sizes() []int { return r.sizes }
 // IsComment returns true if the full line is a comment.
 func (r *Row) IsComment() bool { return r.isComment }
 
+// IsHeader returns true if the row is the table header.
+func (r *Row) IsHeader() bool { return r.isHeader }
+
+// IsFooter returns true if the row is the table footer.
+func (r *Row) IsFooter() bool { return r.isFooter }
+
+// IsBlank returns true if none of the columns hold any data.
+func (r *Row) IsBlank() bool {
+	for _, c := range r.columns {
+		if c.Length() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsData returns true if the row holds data, rather than being a comment,
+// header, footer or blank row.
+func (r *Row) IsData() bool {
+	return !r.isComment && !r.isHeader && !r.isFooter && !r.IsBlank()
+}
+
 // String implements fmt.Stringer.
 func (r *Row) String() string {
 	var buf bytes.Buffer
@@ -85,17 +125,56 @@ type Column struct {
 // Value of the column.
 func (c *Column) Value() string { return c.cell }
 
-// Length of the cell.
-func (c *Column) Length() int { return len(c.cell) }
+// Length of the cell, in terminal display cells. The length of a multi-line
+// cell is that of its longest line.
+func (c *Column) Length() int { return BlockWidth(c.cell) }
+
+// Lines of the cell.
+func (c *Column) Lines() []string { return Lines(c.cell) }
 
 // String implements fmt.Stringer.
 func (c *Column) String() string { return c.cell }
 
+// Section holds a group of consecutive rows of a table. When sections are
+// enabled, they are delineated by empty lines. The empty lines themselves are
+// not part of any section.
+type Section struct {
+	tbl      *Table
+	rows     []*Row
+	colSizes []int
+}
+
+func (t *Table) newSection() *Section {
+	return &Section{tbl: t, rows: []*Row{}}
+}
+
+func (s *Section) add(row *Row) {
+	s.rows = append(s.rows, row)
+	s.colSizes = growSizes(s.colSizes, row)
+}
+
+// Rows returns the row data of the section.
+func (s *Section) Rows() []*Row { return s.rows }
+
+// NumRows returns the number of rows in the section.
+func (s *Section) NumRows() int { return len(s.rows) }
+
+// ColSizes returns the maximum size of each column. If column sizes are not
+// being reset per section, the sizes of the whole table are returned.
+func (s *Section) ColSizes() []int {
+	if !s.tbl.opts.sectionReset {
+		return s.tbl.colSizes
+	}
+	return s.colSizes
+}
+
 type Table struct {
 	opts *options
 
 	rows     []*Row
 	colSizes []int
+	sections []*Section
+	types    []Type // Inferred column types; nil until needed.
 }
 
 func NewTable(opts ...func(*options) error) (*Table, error) {
@@ -103,38 +182,128 @@ func NewTable(opts ...func(*options) error) (*Table, error) {
 	o.setCommentPrefix("#")
 	o.setEnableComments(false)
 	o.setSectionReset(false)
+	o.setHeader(false)
+	o.setJustify(JustifyLeft)
+	o.setJustifyNumbers(false)
+	o.setInput(InputText)
+	o.setCSVQuote('"')
+	o.setCSVLazyQuotes(false)
+	o.setJSONSortKeys(false)
 	for _, opt := range opts {
 		if err := opt(o); err != nil {
 			return nil, err
 		}
 	}
-	return &Table{
+	t := &Table{
 		opts: o,
 		rows: []*Row{},
-	}, nil
+	}
+	t.sections = []*Section{t.newSection()}
+	return t, nil
 }
 
-// Append lines to the table.
+// Append lines to the table. The rows are added to the last section.
 func (t *Table) Append(records ...[]string) {
 	for _, rs := range records {
-		row := newRow(rs, false)
-		for j, s := range row.Sizes() {
-			if len(t.colSizes) > j {
-				t.colSizes[j] = math.Max(t.colSizes[j], s)
-			} else {
-				t.colSizes = append(t.colSizes, s)
-			}
+		t.appendRow(newRow(rs, false))
+	}
+	t.markHeader()
+}
+
+// appendRow adds a row to the last section of the table.
+func (t *Table) appendRow(row *Row) {
+	t.types = nil
+	t.colSizes = growSizes(t.colSizes, row)
+	t.sections[len(t.sections)-1].add(row)
+	t.rows = append(t.rows, row)
+}
+
+// appendBlank adds an empty line to the table. If sections are enabled, the
+// line ends the current section.
+func (t *Table) appendBlank() {
+	row := newRow([]string{""}, false)
+	t.types = nil
+	if !t.opts.sectionReset {
+		t.appendRow(row)
+		return
+	}
+	t.colSizes = growSizes(t.colSizes, row)
+	t.rows = append(t.rows, row)
+	t.sections = append(t.sections, t.newSection())
+}
+
+// Header returns the header row, or nil if the table has no header.
+func (t *Table) Header() *Row {
+	for _, row := range t.rows {
+		if row.IsHeader() {
+			return row
+		}
+	}
+	return nil
+}
+
+// markHeader marks the first data row as the header, if requested.
+func (t *Table) markHeader() {
+	if !t.opts.header || t.Header() != nil {
+		return
+	}
+	for _, row := range t.rows {
+		if !row.IsComment() && !row.IsBlank() && !row.IsFooter() {
+			row.isHeader = true
+			t.types = nil
+			return
 		}
-		t.rows = append(t.rows, row)
 	}
 }
 
 // ColSizes returns the maximum size of each column.
 func (t *Table) ColSizes() []int { return t.colSizes }
 
+// Justification returns the justification of column col.
+func (t *Table) Justification(col int) Justification {
+	if j, ok := t.opts.colJustify[col]; ok {
+		return j
+	}
+	if t.opts.justifyNumbers && t.ColumnType(col).IsNumeric() {
+		return JustifyRight
+	}
+	return t.opts.justify
+}
+
+// NumberJustification returns the justification of column col, as for
+// Justification(), except that numeric columns are right-justified unless
+// their justification was set with ColumnJustifications().
+func (t *Table) NumberJustification(col int) Justification {
+	if _, ok := t.opts.colJustify[col]; !ok && t.ColumnType(col).IsNumeric() {
+		return JustifyRight
+	}
+	return t.Justification(col)
+}
+
+// ColumnTypes returns the type of each column, inferred from the values of the
+// data rows.
+func (t *Table) ColumnTypes() []Type {
+	if t.types == nil {
+		t.types = inferTypes(t.rows, len(t.colSizes))
+	}
+	return t.types
+}
+
+// ColumnType returns the type of column col.
+func (t *Table) ColumnType(col int) Type {
+	if types := t.ColumnTypes(); col < len(types) {
+		return types[col]
+	}
+	return TypeString
+}
+
 // Rows returns the table row data.
 func (t *Table) Rows() []*Row { return t.rows }
 
+// Sections returns the sections of the table. Unless sections are enabled with
+// the SectionReset() option, there is only a single section holding all rows.
+func (t *Table) Sections() []*Section { return t.sections }
+
 // NumRows returns the number of rows in the table.
 func (t *Table) NumRows() int { return len(t.rows) }
 
@@ -152,7 +321,7 @@ func (t *Table) String() string {
 	return fmt.Sprintf("%v sizes: %v\n", buf.String(), t.colSizes)
 }
 
-// Split lines of text into a table.
+// Split lines of text into a table, using runs of the literal separator `ifs`.
 //
 // The count `n` determines the number of substrings to return:
 //
@@ -160,6 +329,12 @@ func (t *Table) String() string {
 //     n == 0: the result is nil (an empty table)
 //     n < 0: all columns
 func Split(lines []string, ifs string, n int, opts ...func(*options) error) (*Table, error) {
+	return SplitWith(lines, NewLiteralSplitter(ifs), n, opts...)
+}
+
+// SplitWith splits lines of text into a table using the given Splitter. The
+// count `n` is interpreted as for Split().
+func SplitWith(lines []string, splitter Splitter, n int, opts ...func(*options) error) (*Table, error) {
 	if n > MAX_COLS {
 		return nil, fmt.Errorf("column count %d exceeds supported maximum number of columns %d", n, MAX_COLS)
 	}
@@ -181,8 +356,9 @@ func Split(lines []string, ifs string, n int, opts ...func(*options) error) (*Ta
 
 	colsSeen := 0
 	rows := []*Row{}
+	sec := tbl.sections[0]
 	for _, line := range lines {
-		row := splitLine(tbl.opts, line, ifs, n)
+		row := splitLine(tbl.opts, line, splitter, n)
 		for j, col := range row.Columns() {
 			if j >= MAX_COLS-1 {
 				j = MAX_COLS - 1
@@ -193,21 +369,84 @@ func Split(lines []string, ifs string, n int, opts ...func(*options) error) (*Ta
 		}
 		rows = append(rows, row)
 		colsSeen = math.Max(row.NumColumns(), colsSeen)
+
+		if tbl.opts.sectionReset && isBlank(line) {
+			sec = tbl.newSection()
+			tbl.sections = append(tbl.sections, sec)
+			continue
+		}
+		sec.add(row)
 	}
 
 	tbl.rows = rows
 	tbl.colSizes = sizes[:colsSeen]
+	tbl.trimSections()
+	tbl.markHeader()
 	return tbl, nil
 }
 
-func splitLine(opts *options, line string, ifs string, columns int) *Row {
+// reset replaces the rows of the table, rebuilding the sections and column
+// sizes. Rows that were not part of any section again end a section, but no
+// more sections are made than there were before.
+func (t *Table) reset(rows []*Row) {
+	numSections := len(t.sections)
+	inSection := map[*Row]bool{}
+	for _, sec := range t.sections {
+		for _, row := range sec.rows {
+			inSection[row] = true
+		}
+	}
+
+	t.rows, t.colSizes, t.types = []*Row{}, nil, nil
+	t.sections = []*Section{t.newSection()}
+	for _, row := range rows {
+		if !inSection[row] {
+			t.colSizes = growSizes(t.colSizes, row)
+			t.rows = append(t.rows, row)
+			t.sections = append(t.sections, t.newSection())
+			continue
+		}
+		t.appendRow(row)
+	}
+	for last := len(t.sections) - 1; last >= numSections && t.sections[last].NumRows() == 0; last-- {
+		t.sections = t.sections[:last]
+	}
+}
+
+// trimSections removes the empty sections left by trailing empty lines.
+func (t *Table) trimSections() {
+	for last := len(t.sections) - 1; last > 0 && t.sections[last].NumRows() == 0; last-- {
+		t.sections = t.sections[:last]
+	}
+}
+
+// isBlank returns true if the line is empty, or holds only whitespace.
+func isBlank(line string) bool { return strings.TrimSpace(line) == "" }
+
+// growSizes returns sizes, grown to hold the column sizes of row. Comment rows
+// do not affect the sizes.
+func growSizes(sizes []int, row *Row) []int {
+	if row.IsComment() {
+		return sizes
+	}
+	for j, s := range row.Sizes() {
+		if len(sizes) > j {
+			sizes[j] = math.Max(sizes[j], s)
+		} else {
+			sizes = append(sizes, s)
+		}
+	}
+	return sizes
+}
+
+func splitLine(opts *options, line string, splitter Splitter, columns int) *Row {
 	isComment := false
 	var recs []string
 	if opts.enableComments && strings.HasPrefix(line, opts.commentPrefix) {
 		recs = []string{line}
 		isComment = true
 	} else {
-		recs = kstrings.SplitNMerged(line, ifs, columns)
+		recs = splitter.Split(line, columns)
 	}
 	return newRow(recs, isComment)
 }
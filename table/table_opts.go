@@ -1,9 +1,20 @@
 package table
 
+import "fmt"
+
 type options struct {
 	commentPrefix  string
 	enableComments bool
 	sectionReset   bool
+	header         bool
+	justify        Justification
+	colJustify     map[int]Justification
+	justifyNumbers bool
+	input          InputFormat
+	positions      []int
+	csvQuote       rune
+	csvLazyQuotes  bool
+	jsonSortKeys   bool
 }
 
 // CommentPrefix is an option for NewTable() that sets the comment prefix.
@@ -36,3 +47,113 @@ func (o *options) setSectionReset(v bool) error {
 	o.sectionReset = v
 	return nil
 }
+
+// Header is a NewTable() option that marks the first non-comment row as the
+// table header.
+func Header(v bool) func(*options) error {
+	return func(o *options) error { return o.setHeader(v) }
+}
+
+func (o *options) setHeader(v bool) error {
+	o.header = v
+	return nil
+}
+
+// Justify is a NewTable() option that sets the default justification of all
+// columns.
+func Justify(v Justification) func(*options) error {
+	return func(o *options) error { return o.setJustify(v) }
+}
+
+func (o *options) setJustify(v Justification) error {
+	o.justify = v
+	return nil
+}
+
+// ColumnJustifications is a NewTable() option that overrides the default
+// justification of the leading columns, one value per column.
+func ColumnJustifications(v []Justification) func(*options) error {
+	return func(o *options) error { return o.setColumnJustifications(v) }
+}
+
+func (o *options) setColumnJustifications(v []Justification) error {
+	if len(v) > MAX_COLS {
+		return fmt.Errorf("%d column justifications exceeds supported maximum number of columns %d", len(v), MAX_COLS)
+	}
+	o.colJustify = map[int]Justification{}
+	for i, j := range v {
+		o.colJustify[i] = j
+	}
+	return nil
+}
+
+// JustifyNumbers is a NewTable() option that right-justifies numeric columns,
+// unless overridden with ColumnJustifications().
+func JustifyNumbers(v bool) func(*options) error {
+	return func(o *options) error { return o.setJustifyNumbers(v) }
+}
+
+func (o *options) setJustifyNumbers(v bool) error {
+	o.justifyNumbers = v
+	return nil
+}
+
+// Input is a Read() option that sets the input format.
+func Input(v InputFormat) func(*options) error {
+	return func(o *options) error { return o.setInput(v) }
+}
+
+func (o *options) setInput(v InputFormat) error {
+	o.input = v
+	return nil
+}
+
+// ColumnPositions is a Read() option that sets the start position of each
+// fixed-width column. If not set, the positions are detected from the data.
+func ColumnPositions(v []int) func(*options) error {
+	return func(o *options) error { return o.setColumnPositions(v) }
+}
+
+func (o *options) setColumnPositions(v []int) error {
+	if len(v) > MAX_COLS {
+		return fmt.Errorf("%d column positions exceeds supported maximum number of columns %d", len(v), MAX_COLS)
+	}
+	o.positions = v
+	return nil
+}
+
+// JSONSortKeys is a ReadJSON() option that sorts the columns built from object
+// keys, rather than keeping them in first-seen order.
+func JSONSortKeys(v bool) func(*options) error {
+	return func(o *options) error { return o.setJSONSortKeys(v) }
+}
+
+func (o *options) setJSONSortKeys(v bool) error {
+	o.jsonSortKeys = v
+	return nil
+}
+
+// CSVQuote is a ReadCSV() option that sets the quote character.
+func CSVQuote(v rune) func(*options) error {
+	return func(o *options) error { return o.setCSVQuote(v) }
+}
+
+func (o *options) setCSVQuote(v rune) error {
+	if v == '\r' || v == '\n' {
+		return fmt.Errorf("invalid CSV quote %q", v)
+	}
+	o.csvQuote = v
+	return nil
+}
+
+// CSVLazyQuotes is a ReadCSV() option that relaxes quote handling. A quote may
+// appear in an unquoted field, and a non-doubled quote may appear in a quoted
+// field.
+func CSVLazyQuotes(v bool) func(*options) error {
+	return func(o *options) error { return o.setCSVLazyQuotes(v) }
+}
+
+func (o *options) setCSVLazyQuotes(v bool) error {
+	o.csvLazyQuotes = v
+	return nil
+}
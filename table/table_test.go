@@ -17,16 +17,14 @@ func TestAppend(t *testing.T) {
 		{"a b c",
 			[][]string{{"a", "b", "c"}},
 			&Row{
-				[]*Column{&Column{"a"}, &Column{"b"}, &Column{"c"}},
-				[]int{1, 1, 1},
-				false},
+				columns: []*Column{&Column{"a"}, &Column{"b"}, &Column{"c"}},
+				sizes:   []int{1, 1, 1}},
 		},
 		{"empty",
 			[][]string{},
 			&Row{
-				[]*Column{},
-				[]int{},
-				false}},
+				columns: []*Column{},
+				sizes:   []int{}}},
 	} {
 		t.Run(fmt.Sprintf("Append() %s", tc.desc), func(t *testing.T) {
 			tbl, err := NewTable()
@@ -163,3 +161,77 @@ func TestParse_MultiRow(t *testing.T) {
 		})
 	}
 }
+
+func TestSplit_Sections(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		lines []string
+		reset bool
+
+		numRows  []int   // Number of rows in each section.
+		colSizes [][]int // Column sizes of each section.
+	}{
+		{"no reset", []string{"1 22", "", "333 4444"}, false,
+			[]int{3}, [][]int{{3, 4}}},
+		{"reset", []string{"1 22", "", "333 4444"}, true,
+			[]int{1, 1}, [][]int{{1, 2}, {3, 4}}},
+		{"reset trailing blank", []string{"1 22", "", "333 4444", ""}, true,
+			[]int{1, 1}, [][]int{{1, 2}, {3, 4}}},
+		{"reset with comment", []string{"# comment", "1 22", "", "333 4444"}, true,
+			[]int{2, 1}, [][]int{{1, 2}, {3, 4}}},
+	} {
+		t.Run(fmt.Sprintf("Split() sections %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(tc.lines, " ", -1, EnableComments(true), SectionReset(tc.reset))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			secs := tbl.Sections()
+			if got, want := len(secs), len(tc.numRows); got != want {
+				t.Fatalf("len(tbl.Sections()) = %d, want %d", got, want)
+			}
+			for i, sec := range secs {
+				if got, want := sec.NumRows(), tc.numRows[i]; got != want {
+					t.Errorf("section #%d: sec.NumRows() = %d, want %d", i, got, want)
+				}
+				if got, want := sec.ColSizes(), tc.colSizes[i]; !operators.EqualSlicesOfInt(got, want) {
+					t.Errorf("section #%d: sec.ColSizes() = %d, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHeader(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		lines  []string
+		header bool
+		want   []string
+	}{
+		{"no header", []string{"a b", "1 2"}, false, nil},
+		{"header", []string{"a b", "1 2"}, true, []string{"a", "b"}},
+		{"header after comment", []string{"# comment", "", "a b", "1 2"}, true, []string{"a", "b"}},
+	} {
+		t.Run(fmt.Sprintf("Header() %s", tc.desc), func(t *testing.T) {
+			tbl, err := Split(tc.lines, " ", -1, EnableComments(true), Header(tc.header))
+			if err != nil {
+				t.Fatalf("unexpected error; %s", err)
+			}
+
+			hdr := tbl.Header()
+			if tc.want == nil {
+				if hdr != nil {
+					t.Errorf("Header() = %q, want nil", hdr.Values())
+				}
+				return
+			}
+			if hdr == nil {
+				t.Fatalf("Header() = nil, want %q", tc.want)
+			}
+			if got, want := hdr.Values(), tc.want; !operators.EqualSlicesOfString(got, want) {
+				t.Errorf("Header() = %q, want %q", got, want)
+			}
+		})
+	}
+}
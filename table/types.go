@@ -0,0 +1,100 @@
+package table
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type describes the type of the values held by a column.
+type Type int
+
+const (
+	TypeString Type = iota
+	TypeInteger
+	TypeFloat
+	TypeBoolean
+	TypeTime
+)
+
+// floatRE matches decimal floating point numbers. Unlike strconv.ParseFloat(),
+// hexadecimal numbers, infinities and NaN are not matched.
+var floatRE = regexp.MustCompile(`^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+
+// timeLayouts are the layouts of date/time values.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ValueType returns the type of a single value. ANSI escape sequences and
+// surrounding whitespace are ignored.
+func ValueType(s string) Type {
+	s = strings.TrimSpace(StripANSI(s))
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return TypeInteger
+	}
+	if floatRE.MatchString(s) {
+		return TypeFloat
+	}
+	switch strings.ToLower(s) {
+	case "true", "false":
+		return TypeBoolean
+	}
+	if _, ok := parseTime(s); ok {
+		return TypeTime
+	}
+	return TypeString
+}
+
+// IsNumeric returns true if the type is a number.
+func (t Type) IsNumeric() bool { return t == TypeInteger || t == TypeFloat }
+
+// String implements fmt.Stringer.
+func (t Type) String() string {
+	switch t {
+	case TypeInteger:
+		return "integer"
+	case TypeFloat:
+		return "float"
+	case TypeBoolean:
+		return "boolean"
+	case TypeTime:
+		return "time"
+	}
+	return "string"
+}
+
+// inferTypes returns the type of each column, inferred from the values of the
+// data rows, before any number formatting. Empty values are ignored. A column
+// holding both integers and floats is a float column, while any other mix of
+// types is a string column, as is a column without any values.
+func inferTypes(rows []*Row, numCols int) []Type {
+	types := make([]Type, numCols)
+	seen := make([]bool, numCols)
+	for _, row := range rows {
+		if row.IsComment() || row.IsHeader() || row.IsFooter() {
+			continue
+		}
+		for j, c := range row.Columns() {
+			if j >= numCols || c.Length() == 0 {
+				continue
+			}
+			t := ValueType(row.rawValue(j))
+			switch {
+			case !seen[j]:
+				types[j], seen[j] = t, true
+			case t == types[j]:
+			case t.IsNumeric() && types[j].IsNumeric():
+				types[j] = TypeFloat
+			default:
+				types[j] = TypeString
+			}
+		}
+	}
+	return types
+}
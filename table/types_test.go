@@ -0,0 +1,60 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValueType(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want Type
+	}{
+		{"123", TypeInteger},
+		{"-7", TypeInteger},
+		{"1.5", TypeFloat},
+		{"1e6", TypeFloat},
+		{".5", TypeFloat},
+		{"NaN", TypeString},
+		{"0x1p-2", TypeString},
+		{"TRUE", TypeBoolean},
+		{"2024-01-02", TypeTime},
+		{"2024-01-02T03:04:05Z", TypeTime},
+		{"\x1b[1m42\x1b[0m", TypeInteger},
+		{"abc", TypeString},
+	} {
+		t.Run(fmt.Sprintf("ValueType(%q)", tc.s), func(t *testing.T) {
+			if got, want := ValueType(tc.s), tc.want; got != want {
+				t.Errorf("= %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	tbl, err := Split([]string{
+		"# comment",
+		"int float mixed bool empty",
+		"1 1 a true",
+		"2 2.5 1 false",
+	}, " ", -1, EnableComments(true), Header(true), JustifyNumbers(true))
+	if err != nil {
+		t.Fatalf("unexpected error; %s", err)
+	}
+
+	want := []Type{TypeInteger, TypeFloat, TypeString, TypeBoolean, TypeString}
+	if got := tbl.ColumnTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ColumnTypes() = %v, want %v", got, want)
+	}
+	for j, want := range []Justification{JustifyRight, JustifyRight, JustifyLeft, JustifyLeft, JustifyLeft} {
+		if got := tbl.Justification(j); got != want {
+			t.Errorf("Justification(%d) = %v, want %v", j, got, want)
+		}
+	}
+
+	tbl.Append([]string{"x"})
+	if got, want := tbl.ColumnType(0), TypeString; got != want {
+		t.Errorf("ColumnType(0) after Append() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ansiPrefixRE matches an ANSI escape sequence at the start of a string.
+var ansiPrefixRE = regexp.MustCompile(`^(?:` + ansiRE.String() + `)`)
+
+// sgrRE matches an SGR (colour and style) escape sequence, capturing its
+// parameters.
+var sgrRE = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// sgrReset ends all SGR colours and styles.
+const sgrReset = "\x1b[0m"
+
+// Width returns the number of terminal display cells needed to show s. East
+// Asian wide characters occupy two cells, while combining marks and other
+// zero-width characters occupy none. ANSI escape sequences are not displayed,
+// and occupy no cells.
+func Width(s string) int { return runewidth.StringWidth(StripANSI(s)) }
+
+// Lines returns the lines of s. Lines are ended by "\n" or "\r\n".
+func Lines(s string) []string {
+	if !strings.ContainsRune(s, '\n') {
+		return []string{s}
+	}
+	ls := strings.Split(s, "\n")
+	for i, l := range ls {
+		ls[i] = strings.TrimSuffix(l, "\r")
+	}
+	return ls
+}
+
+// BlockWidth returns the width of the widest line of s.
+func BlockWidth(s string) int {
+	w := 0
+	for _, l := range Lines(s) {
+		if lw := Width(l); lw > w {
+			w = lw
+		}
+	}
+	return w
+}
+
+// Truncate returns s cut to at most width display cells. If s is cut, trailing
+// spaces are trimmed and tail (e.g. an ellipsis) is appended, counting towards
+// the width. Colours and styles still active at the cut are reset after the
+// tail.
+func Truncate(s string, width int, tail string) string {
+	if Width(s) <= width {
+		return s
+	}
+	w := width - Width(tail)
+	if w <= 0 {
+		return tail
+	}
+	head, _ := splitWidth(s, w)
+	head = strings.TrimRight(head, " ")
+	if sgrState(head) != "" {
+		return head + tail + sgrReset
+	}
+	return head + tail
+}
+
+// Wrap breaks s into lines of at most width display cells. Lines are broken
+// between words where possible, and words wider than width are broken wherever
+// needed. Runs of spaces between words are collapsed. Colours and styles
+// active at the end of a line are reset, and restored on the next line.
+func Wrap(s string, width int) []string {
+	if width <= 0 || Width(s) <= width {
+		return []string{s}
+	}
+
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(s) {
+		if line != "" {
+			if Width(line)+1+Width(word) <= width {
+				line += " " + word
+				continue
+			}
+			lines = append(lines, line)
+		}
+		for Width(word) > width {
+			var head string
+			head, word = splitWidth(word, width)
+			lines = append(lines, head)
+		}
+		line = word
+	}
+	lines = append(lines, line)
+
+	active := ""
+	for i, l := range lines {
+		l = active + l
+		if active = sgrState(l); active != "" {
+			l += sgrReset
+		}
+		lines[i] = l
+	}
+	return lines
+}
+
+// sgrState returns the SGR escape sequences still in effect at the end of s,
+// or an empty string if none are.
+func sgrState(s string) string {
+	if !strings.ContainsRune(s, '\x1b') {
+		return ""
+	}
+	state := ""
+	for _, m := range sgrRE.FindAllStringSubmatch(s, -1) {
+		switch params := m[1]; {
+		case params == "" || params == "0":
+			state = ""
+		case strings.HasPrefix(params, "0;"):
+			state = m[0]
+		default:
+			state += m[0]
+		}
+	}
+	return state
+}
+
+// splitWidth splits s after at most width display cells. At least one
+// character is always placed in the head, even if it is wider than width. ANSI
+// escape sequences occupy no cells, and are never broken.
+func splitWidth(s string, width int) (string, string) {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if loc := ansiPrefixRE.FindStringIndex(s[i:]); loc != nil {
+				i += loc[1]
+				continue
+			}
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runewidth.RuneWidth(r)
+		if n+rw > width && n > 0 {
+			return s[:i], s[i:]
+		}
+		n += rw
+		i += size
+	}
+	return s, ""
+}
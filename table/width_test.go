@@ -0,0 +1,101 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		s    string
+		want int
+	}{
+		{"ascii", "abc", 3},
+		{"precomposed accent", "caf\u00e9", 4},
+		{"combining accent", "cafe\u0301", 4},
+		{"cjk", "日本語", 6},
+		{"emoji", "\U0001F600", 2},
+		{"empty", "", 0},
+	} {
+		t.Run(fmt.Sprintf("Width() %s", tc.desc), func(t *testing.T) {
+			if got, want := Width(tc.s), tc.want; got != want {
+				t.Errorf("Width(%q) = %d, want %d", tc.s, got, want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fits", "abc", 3, "abc"},
+		{"cut", "abcdef", 4, "abc…"},
+		{"cjk", "日本語", 5, "日本…"},
+		{"cjk odd", "日本語", 4, "日…"},
+		{"ansi", "\x1b[1mabcdef\x1b[0m", 4, "\x1b[1mabc…\x1b[0m"},
+		{"ansi closed", "\x1b[1mab\x1b[0mcdef", 4, "\x1b[1mab\x1b[0mc…"},
+		{"trailing space", "the quick brown", 11, "the quick…"},
+		{"tail only", "abc", 1, "…"},
+	} {
+		t.Run(fmt.Sprintf("Truncate() %s", tc.desc), func(t *testing.T) {
+			if got, want := Truncate(tc.s, tc.width, "…"), tc.want; got != want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tc.s, tc.width, got, want)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		s     string
+		width int
+		want  []string
+	}{
+		{"fits", "a b", 3, []string{"a b"}},
+		{"words", "the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"long word", "abcdefgh ij", 3, []string{"abc", "def", "gh", "ij"}},
+		{"long word joined", "ab cdefgh", 4, []string{"ab", "cdef", "gh"}},
+		{"cjk", "日本語", 4, []string{"日本", "語"}},
+		{"spaces", "a    b", 1, []string{"a", "b"}},
+		{"no width", "abc", 0, []string{"abc"}},
+		{"ansi", "\x1b[31mab cd\x1b[0m", 2, []string{"\x1b[31mab\x1b[0m", "\x1b[31mcd\x1b[0m"}},
+		{"ansi long word", "\x1b[1;31mabcd\x1b[0m ef", 2,
+			[]string{"\x1b[1;31mab\x1b[0m", "\x1b[1;31mcd\x1b[0m", "ef"}},
+	} {
+		t.Run(fmt.Sprintf("Wrap() %s", tc.desc), func(t *testing.T) {
+			if got, want := Wrap(tc.s, tc.width), tc.want; !reflect.DeepEqual(got, want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tc.s, tc.width, got, want)
+			}
+		})
+	}
+}
+
+func TestBlockWidth(t *testing.T) {
+	for _, tc := range []struct {
+		desc  string
+		s     string
+		lines []string
+		width int
+	}{
+		{"single line", "abc", []string{"abc"}, 3},
+		{"multi-line", "a\nbcd\nef", []string{"a", "bcd", "ef"}, 3},
+		{"crlf", "ab\r\nc", []string{"ab", "c"}, 2},
+		{"trailing newline", "ab\n", []string{"ab", ""}, 2},
+	} {
+		t.Run(fmt.Sprintf("BlockWidth() %s", tc.desc), func(t *testing.T) {
+			if got, want := Lines(tc.s), tc.lines; !reflect.DeepEqual(got, want) {
+				t.Errorf("Lines(%q) = %q, want %q", tc.s, got, want)
+			}
+			if got, want := BlockWidth(tc.s), tc.width; got != want {
+				t.Errorf("BlockWidth(%q) = %d, want %d", tc.s, got, want)
+			}
+		})
+	}
+}
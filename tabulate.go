@@ -1,42 +1,125 @@
 package main
 
 import (
-	"bufio"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/kward/tabulate/render"
 	"github.com/kward/tabulate/table"
+	"golang.org/x/term"
 )
 
 var (
 	columns        int
 	ifs, ofs       string
 	renderer       string
+	input          string
 	enableComments bool
 	commentPrefix  string
 	sectionReset   bool
+	justify        string
+	header         bool
+	csvFiles       string
+	stripANSI      bool
+	csvQuote       string
+	csvLazyQuotes  bool
+	positions      string
+	jsonSortKeys   bool
+	htmlComments   string
+	htmlStandalone bool
+	jsonPretty     bool
+	jsonNumbers    bool
+	sqlDialect     string
+	sqlTable       string
+	sqlBatch       int
+	boxRowLines    bool
+	psqlExpanded   bool
+	verticalRule   bool
+	maxWidth       int
+	overflow       string
+	minWidths      string
+	maxWidths      string
+	justifyNumbers bool
+	decimals       int
+	thousands      string
+	alignDecimals  bool
+	sortKeys       string
+	selectColumns  string
+	where          string
+	groupBy        string
+	aggregates     string
+	footer         string
+	csvFooter      bool
+	pivot          int
+	transpose      bool
 )
 
 func flagInit(rs []render.Renderer) {
 	// Flag initialization.
-	flag.Int("cols", 0, "Number of columns; 0=all.")
+	flag.IntVar(&columns, "cols", 0, "Number of columns; 0=all.")
 
-	flag.StringVar(&ifs, "I", " ", "Input field separator.")
+	flag.StringVar(&ifs, "I", " ", "Input field separator; a regular expression for the regexp input format.")
 	flag.StringVar(&ofs, "O", " ", "Output field separator.")
 	flag.StringVar(&renderer, "r", "plain", "Output renderer.")
+	flag.StringVar(&input, "i", "text", "Input format.")
+
+	flag.StringVar(&positions, "positions", "", "Fixed-width input column start positions, comma-separated; detected when empty.")
+	flag.BoolVar(&jsonSortKeys, "json_sort_keys", false, "Sort the columns of JSON object input by key.")
+	flag.StringVar(&csvQuote, "csv_quote", `"`, "CSV input quote character.")
+	flag.BoolVar(&csvLazyQuotes, "csv_lazy_quotes", false, "Allow bare and non-doubled quotes in CSV input.")
 
 	flag.BoolVar(&enableComments, "enable_comments", true, "Enable comments.")
 	flag.StringVar(&commentPrefix, "comment_prefix", "#", "Comment prefix.")
 
 	flag.BoolVar(&sectionReset, "R", false, "Reset column widths after each section.")
+	flag.BoolVar(&header, "H", false, "First non-comment row is a header. (shorthand)")
+	flag.BoolVar(&header, "header", false, "First non-comment row is a header.")
+	flag.StringVar(&where, "where", "", `Keep rows matching an expression, e.g. '$3 >= 100 && shell =~ "bash$"'. Columns are referenced by $index or header name.`)
+	flag.StringVar(&groupBy, "group_by", "", "Collapse rows into one per group of the comma-separated one-based columns, after filtering.")
+	flag.StringVar(&groupBy, "group-by", "", "Alias of --group_by.")
+	flag.StringVar(&aggregates, "agg", "count", "Aggregates of each group, e.g. 'count,sum:3,avg:3,max:4'; count, sum, avg, min or max.")
+	flag.StringVar(&footer, "footer", "", "Append a footer row, with one comma-separated cell per column; count, sum, avg, min or max aggregate the column, anything else is a label. E.g. 'Total,,sum'.")
+	flag.BoolVar(&csvFooter, "csv_footer", false, "Include the footer row in csv output.")
+	flag.IntVar(&pivot, "pivot", 0, "Spread the values of this one-based column into columns, with rows grouped by --group_by and cells holding the single --agg aggregate; 0=none.")
+	flag.BoolVar(&transpose, "transpose", false, "Swap rows and columns, after selecting columns.")
+	flag.StringVar(&sortKeys, "sort", "", "Sort rows by comma-separated one-based columns, e.g. '3n,-1'. A '-' prefix sorts descending; an l (lexical), n (numeric), v (natural) or d (date) suffix sets the comparison.")
+	flag.StringVar(&selectColumns, "columns", "", "Select columns, after sorting, by comma-separated one-based index, range or header name, e.g. '1,5-7,-1' or 'name,shell=login'. A '=name' suffix renames a column.")
+	flag.BoolVar(&justifyNumbers, "justify_numbers", false, "Right-justify numeric columns, unless justified with a list of -J values.")
+	flag.IntVar(&decimals, "decimals", -1, "Decimal places of float columns; -1=unchanged.")
+	flag.StringVar(&thousands, "thousands", "", "Thousands separator of numeric columns, e.g. ','.")
+	flag.BoolVar(&alignDecimals, "align_decimals", false, "Align float columns on the decimal point in plain, mysql, box and psql output; use with right justification.")
+	flag.BoolVar(&stripANSI, "strip_ansi", false, "Strip ANSI escape sequences from csv and sqlite3 output.")
+	flag.StringVar(&htmlComments, "html_comments", "omit", "How html output renders comments; omit, caption or markup.")
+	flag.BoolVar(&htmlStandalone, "html_standalone", false, "Render html output as a standalone document.")
+	flag.BoolVar(&jsonPretty, "json_pretty", false, "Indent json and ndjson output.")
+	flag.BoolVar(&jsonNumbers, "json_numbers", false, "Render numeric values as numbers in json and ndjson output.")
+	flag.BoolVar(&boxRowLines, "box_row_lines", false, "Draw a line between every row of box output.")
+	flag.BoolVar(&psqlExpanded, "x", false, "Expanded display of psql output, with one block per record.")
+	flag.StringVar(&sqlDialect, "sql_dialect", "sqlite", "SQL dialect of sql output; sqlite, mysql or postgres.")
+	flag.StringVar(&sqlTable, "sql_table", "data", "Table name of sql output.")
+	flag.IntVar(&sqlBatch, "sql_batch", 100, "Maximum rows per INSERT statement of sql output; 0=all.")
+	flag.BoolVar(&verticalRule, "vertical_rule", false, "Precede each record of vertical output with a numbered rule, rather than a blank line.")
+	flag.IntVar(&maxWidth, "width", 0, "Maximum width of box, mysql and plain output; 0=terminal width when output is a terminal, -1=unlimited.")
+	flag.StringVar(&overflow, "overflow", "wrap", "How values wider than their fitted column are handled; wrap or truncate.")
+	flag.StringVar(&minWidths, "min_widths", "", "Minimum width of each column of fitted output, comma-separated; 0=none.")
+	flag.StringVar(&maxWidths, "max_widths", "", "Maximum width of each column of fitted output, comma-separated; 0=none.")
+	flag.StringVar(&csvFiles, "csv_files", "", "Write each section to a separate CSV file, named with this prefix.")
+
+	flag.StringVar(&justify, "J", "left", "Column justification; comma-separated list of left, right or center (l, r, c). A single value applies to all columns.")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
 		flag.PrintDefaults()
 
+		fmt.Fprintln(os.Stderr, "Supported input formats:")
+		for _, f := range table.InputFormats {
+			fmt.Fprintf(os.Stderr, "  %v\n", f)
+		}
 		fmt.Fprintln(os.Stderr, "Supported renderers:")
 		for _, r := range rs {
 			fmt.Fprintf(os.Stderr, "  %v\n", r.Type())
@@ -51,22 +134,55 @@ func flagInit(rs []render.Renderer) {
 	}
 }
 
-func read(fh *os.File, data *[]string) error {
-	s := bufio.NewScanner(fh)
-	for s.Scan() {
-		*data = append(*data, s.Text())
+// flagSet returns true if the named flag was set on the command line.
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseJustify parses the justification flag. A single value sets the default
+// justification, while a list sets the justification of each column.
+func parseJustify(v string) (table.Justification, []table.Justification, error) {
+	fields := strings.Split(v, ",")
+	js := make([]table.Justification, len(fields))
+	for i, f := range fields {
+		j, err := table.ParseJustification(strings.TrimSpace(f))
+		if err != nil {
+			return table.JustifyLeft, nil, err
+		}
+		js[i] = j
 	}
-	if err := s.Err(); err != nil {
-		return fmt.Errorf("ERROR Reading file: %v", err)
+	if len(js) == 1 {
+		return js[0], nil, nil
 	}
-	return nil
+	return table.JustifyLeft, js, nil
+}
+
+// parseInts parses a comma-separated list of integers. An empty string returns
+// a nil list.
+func parseInts(v string) ([]int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	fields := strings.Split(v, ",")
+	is := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, err
+		}
+		is[i] = n
+	}
+	return is, nil
 }
 
 func main() {
-	var (
-		err  error
-		data []string
-	)
+	var err error
 
 	flagInit(render.Renderers)
 
@@ -74,10 +190,16 @@ func main() {
 	for _, r := range render.Renderers {
 		renderers[r.Type()] = r
 	}
-	_, ok := renderers[renderer]
+	r, ok := renderers[renderer]
 	if !ok {
 		log.Fatalf("Invalid --render flag value %v.", renderer)
 	}
+	if sectionReset && !r.SectionsSupported() {
+		log.Fatalf("The %v renderer does not support sections (-R).", r.Type())
+	}
+	if csvFiles != "" && r.Type() != "csv" {
+		log.Fatalf("The --csv_files flag requires the csv renderer.")
+	}
 
 	// Open file.
 	fh := os.Stdin
@@ -89,31 +211,207 @@ func main() {
 		defer fh.Close()
 	}
 
-	// Read file.
-	err = read(fh, &data)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// Parse file.
+	// Read and parse file.
 	n := columns
 	if n == 0 {
 		n = -1
 	}
-	tbl, err := table.Split(data, ifs, n,
+	inputFormat, err := table.ParseInputFormat(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if inputFormat == table.InputCSV && !flagSet("I") {
+		ifs = ","
+	}
+	quote, size := utf8.DecodeRuneInString(csvQuote)
+	if size == 0 || size != len(csvQuote) {
+		log.Fatalf("Invalid --csv_quote flag value %q.", csvQuote)
+	}
+	colPositions, err := parseInts(positions)
+	if err != nil {
+		log.Fatalf("Invalid --positions flag value %q; %s", positions, err)
+	}
+	defJustify, colJustify, err := parseJustify(justify)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tbl, err := table.Read(fh, ifs, n,
+		table.Input(inputFormat),
+		table.ColumnPositions(colPositions),
+		table.CSVQuote(quote),
+		table.CSVLazyQuotes(csvLazyQuotes),
+		table.JSONSortKeys(jsonSortKeys),
 		table.CommentPrefix(commentPrefix),
 		table.EnableComments(enableComments),
 		table.SectionReset(sectionReset),
+		table.Header(header),
+		table.Justify(defJustify),
+		table.ColumnJustifications(colJustify),
+		table.JustifyNumbers(justifyNumbers),
 	)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if where != "" {
+		e, err := table.ParseExpr(where)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := tbl.Filter(e); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if groupBy != "" || pivot > 0 {
+		cols, err := parseInts(groupBy)
+		if err != nil {
+			log.Fatalf("Invalid --group_by flag value %q; %s", groupBy, err)
+		}
+		for i := range cols {
+			cols[i]--
+		}
+		aggs, err := table.ParseAggregates(aggregates)
+		if err != nil {
+			log.Fatal(err)
+		}
+		switch {
+		case pivot > 0 && len(aggs) != 1:
+			log.Fatalf("The --pivot flag requires a single --agg aggregate.")
+		case pivot > 0:
+			tbl, err = tbl.Pivot(cols, pivot-1, aggs[0])
+		default:
+			tbl, err = tbl.GroupBy(cols, aggs...)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if sortKeys != "" {
+		keys, err := table.ParseSortKeys(sortKeys)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := tbl.Sort(keys...); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if selectColumns != "" {
+		sels, err := table.ParseColumnSelectors(selectColumns)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := tbl.Select(sels...); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if transpose {
+		tbl = tbl.Transpose()
+	}
+	if footer != "" {
+		if err := tbl.AppendFooter(table.ParseFooter(footer)...); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if decimals >= 0 || thousands != "" {
+		tbl.FormatNumbers(table.NumberFormat{
+			Decimals:  decimals,
+			Thousands: thousands,
+		})
+	}
 
 	// Render file.
-	r := renderers[renderer]
+	if f, ok := r.(render.Fitter); ok {
+		o, err := render.ParseOverflow(overflow)
+		if err != nil {
+			log.Fatal(err)
+		}
+		mins, err := parseInts(minWidths)
+		if err != nil {
+			log.Fatalf("Invalid --min_widths flag value %q; %s", minWidths, err)
+		}
+		maxs, err := parseInts(maxWidths)
+		if err != nil {
+			log.Fatalf("Invalid --max_widths flag value %q; %s", maxWidths, err)
+		}
+		f.SetMaxWidth(terminalWidth(maxWidth))
+		f.SetOverflow(o)
+		f.SetColumnWidths(mins, maxs)
+	}
+	if a, ok := r.(render.PointAligner); ok {
+		a.SetAlignPoint(alignDecimals)
+	}
 	switch r.(type) {
+	case *render.BoxRenderer:
+		r.(*render.BoxRenderer).SetRowLines(boxRowLines)
+	case *render.CSVRenderer:
+		r.(*render.CSVRenderer).SetStripANSI(stripANSI)
+		r.(*render.CSVRenderer).SetFooter(csvFooter)
+		if csvFiles != "" {
+			if err := writeSections(r.(*render.CSVRenderer).RenderSections(tbl), csvFiles, "csv"); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	case *render.HTMLRenderer:
+		c, err := render.ParseHTMLComment(htmlComments)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.(*render.HTMLRenderer).SetComment(c)
+		r.(*render.HTMLRenderer).SetStandalone(htmlStandalone)
+	case *render.JSONRenderer:
+		r.(*render.JSONRenderer).SetPretty(jsonPretty)
+		r.(*render.JSONRenderer).SetNumbers(jsonNumbers)
+	case *render.NDJSONRenderer:
+		r.(*render.NDJSONRenderer).SetPretty(jsonPretty)
+		r.(*render.NDJSONRenderer).SetNumbers(jsonNumbers)
 	case *render.PlainRenderer:
 		r.(*render.PlainRenderer).SetOFS(ofs)
+	case *render.PSQLRenderer:
+		r.(*render.PSQLRenderer).SetExpanded(psqlExpanded)
+	case *render.SQLRenderer:
+		d, err := render.ParseSQLDialect(sqlDialect)
+		if err != nil {
+			log.Fatal(err)
+		}
+		r.(*render.SQLRenderer).SetDialect(d)
+		r.(*render.SQLRenderer).SetTableName(sqlTable)
+		r.(*render.SQLRenderer).SetBatchSize(sqlBatch)
+	case *render.SQLite3Renderer:
+		r.(*render.SQLite3Renderer).SetStripANSI(stripANSI)
+	case *render.VerticalRenderer:
+		r.(*render.VerticalRenderer).SetRule(verticalRule)
 	}
 	fmt.Print(r.Render(tbl))
 }
+
+// terminalWidth returns the maximum output width for the --width flag value.
+// A width of zero is replaced with the width of the terminal, if the output is
+// a terminal, while a negative width is unlimited.
+func terminalWidth(w int) int {
+	switch {
+	case w > 0:
+		return w
+	case w < 0:
+		return 0
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	w, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return w
+}
+
+// writeSections writes each rendered section to a separate, numbered file.
+func writeSections(docs []string, prefix, ext string) error {
+	for i, doc := range docs {
+		fn := fmt.Sprintf("%s%d.%s", prefix, i+1, ext)
+		if err := os.WriteFile(fn, []byte(doc), 0644); err != nil {
+			return fmt.Errorf("ERROR Writing file: %v", err)
+		}
+	}
+	return nil
+}